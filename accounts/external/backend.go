@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -168,15 +169,27 @@ func (api *ExternalSigner) SignData(account accounts.Account, mimeType string, d
 	var res hexutil.Bytes
 
 	var signAddress = common.NewMixedcaseAddress(account.Address)
-	if err := api.client.Call(&res, "account_signData",
+
+	// Bor headers go through the dedicated endpoint enforcing the anti double sign rules
+	if mimeType == accounts.MimetypeBor {
+		if err := api.client.Call(&res, "account_signBorHeader",
+			&signAddress, // Need to use the pointer here, because of how MarshalJSON is defined
+			hexutil.Encode(data)); err != nil {
+			return nil, err
+		}
+	} else if err := api.client.Call(&res, "account_signData",
 		mimeType,
 		&signAddress, // Need to use the pointer here, because of how MarshalJSON is defined
 		hexutil.Encode(data)); err != nil {
 		return nil, err
 	}
-	// If V is on 27/28-form, convert to 0/1 for Clique
-	if mimeType == accounts.MimetypeClique && (res[64] == 27 || res[64] == 28) {
-		res[64] -= 27 // Transform V from 27/28 to 0/1 for Clique use
+
+	if len(res) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d from external signer", len(res))
+	}
+	// If V is on 27/28-form, convert to 0/1 for Clique and Bor
+	if (mimeType == accounts.MimetypeClique || mimeType == accounts.MimetypeBor) && (res[64] == 27 || res[64] == 28) {
+		res[64] -= 27 // Transform V from 27/28 to 0/1 for Clique and Bor use
 	}
 
 	return res, nil
@@ -720,8 +720,9 @@ func signer(c *cli.Context) error {
 	log.Info("Loaded 4byte database", "embeds", embeds, "locals", locals, "local", fourByteLocal)
 
 	var (
-		api       core.ExternalAPI
-		pwStorage storage.Storage = &storage.NoStorage{}
+		api        core.ExternalAPI
+		pwStorage  storage.Storage = &storage.NoStorage{}
+		borStorage storage.Storage
 	)
 
 	configDir := c.String(configdirFlag.Name)
@@ -735,11 +736,13 @@ func signer(c *cli.Context) error {
 		pwkey := crypto.Keccak256([]byte("credentials"), stretchedKey)
 		jskey := crypto.Keccak256([]byte("jsstorage"), stretchedKey)
 		confkey := crypto.Keccak256([]byte("config"), stretchedKey)
+		borkey := crypto.Keccak256([]byte("borheaders"), stretchedKey)
 
 		// Initialize the encrypted storages
 		pwStorage = storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "credentials.json"), pwkey)
 		jsStorage := storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "jsstorage.json"), jskey)
 		configStorage := storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "config.json"), confkey)
+		borStorage = storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "borheaders.json"), borkey)
 
 		// Do we have a rule-file?
 		if ruleFile := c.String(ruleFlag.Name); ruleFile != "" {
@@ -784,6 +787,13 @@ func signer(c *cli.Context) error {
 	am := core.StartClefAccountManager(ksLoc, nousb, lightKdf, scpath)
 	apiImpl := core.NewSignerAPI(am, chainId, nousb, ui, db, advanced, pwStorage)
 
+	// Keep track of the signed bor headers across restarts, to never sign two at the same height
+	if borStorage != nil {
+		apiImpl.SetBorHeaderStorage(borStorage)
+	} else {
+		log.Warn("Signed bor headers are not persisted without a master seed")
+	}
+
 	// Establish the bidirectional communication, by creating a new UI backend and registering
 	// it with the UI.
 	ui.RegisterUIServer(core.NewUIServerAPI(apiImpl))
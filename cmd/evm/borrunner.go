@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/tests"
+)
+
+var borTestCommand = &cli.Command{
+	Action:    borTestCmd,
+	Name:      "bortest",
+	Usage:     "executes the given bor consensus conformance tests",
+	ArgsUsage: "<file>",
+}
+
+func borTestCmd(ctx *cli.Context) error {
+	if len(ctx.Args().First()) == 0 {
+		return errors.New("path-to-test argument required")
+	}
+	// Configure the go-ethereum logger
+	glogger := log.NewGlogHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(false)))
+	glogger.Verbosity(log.Lvl(ctx.Int(VerbosityFlag.Name)))
+	log.Root().SetHandler(glogger)
+
+	// Load the test content from the input file
+	src, err := os.ReadFile(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	var tests map[string]tests.BorTest
+
+	if err = json.Unmarshal(src, &tests); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(tests))
+	for name := range tests {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		test := tests[name]
+		if err := test.Run(); err != nil {
+			return fmt.Errorf("test %v: %w", name, err)
+		}
+
+		fmt.Fprintf(os.Stderr, "test %v: pass\n", name)
+	}
+
+	return nil
+}
@@ -220,6 +220,7 @@ func init() {
 		disasmCommand,
 		runCommand,
 		blockTestCommand,
+		borTestCommand,
 		stateTestCommand,
 		stateTransitionCommand,
 		transactionCommand,
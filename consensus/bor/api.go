@@ -1,7 +1,9 @@
 package bor
 
 import (
+	"context"
 	"encoding/hex"
+	"errors"
 	"math"
 	"math/big"
 	"sort"
@@ -10,9 +12,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -23,6 +28,22 @@ import (
 var (
 	// MaxCheckpointLength is the maximum number of blocks that can be requested for constructing a checkpoint root hash
 	MaxCheckpointLength = uint64(math.Pow(2, 15))
+
+	errNoHeimdallClient = errors.New("heimdall client not available")
+)
+
+const (
+	// maxSpanLookahead is the number of spans past the current one searched for a future block
+	maxSpanLookahead = 2
+
+	// maxValidatorSetHistory is the maximum number of spans returned by GetValidatorSetHistory
+	maxValidatorSetHistory = 128
+
+	// maxProducerSchedule is the maximum number of blocks returned by GetProducerSchedule
+	maxProducerSchedule = 1024
+
+	// maxStateSyncRange is the maximum number of state syncs returned by GetStateSyncsByRange
+	maxStateSyncRange = 1024
 )
 
 // API is a user facing RPC API to allow controlling the signer and voting
@@ -271,6 +292,384 @@ func (api *API) GetCurrentValidators() ([]*valset.Validator, error) {
 	return snap.ValidatorSet.Validators, nil
 }
 
+// GetSpan returns the span with the given id as known to heimdall.
+func (api *API) GetSpan(ctx context.Context, id uint64) (*span.HeimdallSpan, error) {
+	if api.bor.HeimdallClient == nil {
+		return nil, errNoHeimdallClient
+	}
+
+	return api.bor.HeimdallClient.Span(ctx, id)
+}
+
+// GetSpanByBlock returns the span the given block belongs to. Blocks ahead of
+// the head are looked up in the spans already known to heimdall.
+func (api *API) GetSpanByBlock(ctx context.Context, number rpc.BlockNumber) (*span.HeimdallSpan, error) {
+	head := api.chain.CurrentHeader()
+
+	blockNumber := head.Number.Uint64()
+	if number >= 0 {
+		blockNumber = uint64(number)
+	}
+
+	return api.spanByBlock(ctx, head, blockNumber)
+}
+
+// spanByBlock returns the span the given block belongs to. Only the current
+// span is read from the state of the head, the spans preceding it are resolved
+// from their bounds in heimdall, as the historical state may be pruned.
+func (api *API) spanByBlock(ctx context.Context, head *types.Header, number uint64) (*span.HeimdallSpan, error) {
+	if api.bor.HeimdallClient == nil {
+		return nil, errNoHeimdallClient
+	}
+
+	current, err := api.bor.spanner.GetCurrentSpan(ctx, head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if number < current.StartBlock {
+		return api.searchSpan(ctx, number, current.ID)
+	}
+
+	for id := current.ID; id <= current.ID+maxSpanLookahead; id++ {
+		heimdallSpan, err := api.bor.HeimdallClient.Span(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if number < heimdallSpan.StartBlock {
+			break
+		}
+
+		if number <= heimdallSpan.EndBlock {
+			return heimdallSpan, nil
+		}
+	}
+
+	return nil, &SpanNotFoundError{Number: number}
+}
+
+// searchSpan binary searches the spans preceding the given one for the span the
+// block belongs to. Spans are contiguous ranges of blocks ordered by id.
+func (api *API) searchSpan(ctx context.Context, number uint64, before uint64) (*span.HeimdallSpan, error) {
+	low, high := uint64(0), before
+
+	for low < high {
+		mid := low + (high-low)/2
+
+		heimdallSpan, err := api.bor.HeimdallClient.Span(ctx, mid)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case number < heimdallSpan.StartBlock:
+			high = mid
+		case number > heimdallSpan.EndBlock:
+			low = mid + 1
+		default:
+			return heimdallSpan, nil
+		}
+	}
+
+	return nil, &SpanNotFoundError{Number: number}
+}
+
+// ValidatorSetPeriod is the set of block producers of the blocks of a span.
+type ValidatorSetPeriod struct {
+	SpanID     uint64             `json:"spanId"`
+	StartBlock uint64             `json:"startBlock"`
+	EndBlock   uint64             `json:"endBlock"`
+	Producers  []valset.Validator `json:"producers"`
+}
+
+// GetValidatorSetHistory returns the block producers of every span overlapping
+// the given range of blocks.
+func (api *API) GetValidatorSetHistory(ctx context.Context, from rpc.BlockNumber, to rpc.BlockNumber) ([]*ValidatorSetPeriod, error) {
+	head := api.chain.CurrentHeader()
+
+	start, end := head.Number.Uint64(), head.Number.Uint64()
+	if from >= 0 {
+		start = uint64(from)
+	}
+
+	if to >= 0 {
+		end = uint64(to)
+	}
+
+	if start > end {
+		return nil, &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: head.Number.Uint64()}
+	}
+
+	heimdallSpan, err := api.spanByBlock(ctx, head, start)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]*ValidatorSetPeriod, 0)
+
+	for {
+		history = append(history, &ValidatorSetPeriod{
+			SpanID:     heimdallSpan.ID,
+			StartBlock: heimdallSpan.StartBlock,
+			EndBlock:   heimdallSpan.EndBlock,
+			Producers:  heimdallSpan.SelectedProducers,
+		})
+
+		if heimdallSpan.EndBlock >= end {
+			return history, nil
+		}
+
+		if len(history) == maxValidatorSetHistory {
+			return nil, &MaxValidatorSetHistoryExceededError{Start: start, End: end}
+		}
+
+		if heimdallSpan, err = api.bor.HeimdallClient.Span(ctx, heimdallSpan.ID+1); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// BackupProducer is a validator allowed to produce a block in place of the
+// primary producer, after waiting for its turn.
+type BackupProducer struct {
+	Signer     common.Address `json:"signer"`
+	Succession int            `json:"succession"`
+	Delay      uint64         `json:"delay"`
+}
+
+// ProducerSlot is the expected production of a block. Delays are relative to
+// the parent block, the expected time assumes every earlier block of the
+// schedule is produced by its primary producer.
+type ProducerSlot struct {
+	Number       uint64           `json:"number"`
+	Primary      common.Address   `json:"primary"`
+	Delay        uint64           `json:"delay"`
+	ExpectedTime uint64           `json:"expectedTime"`
+	Backups      []BackupProducer `json:"backups"`
+}
+
+// GetProducerSchedule returns the primary and backup producers of up to count
+// blocks starting at fromBlock. The schedule is computed from the validator set
+// of the snapshot preceding the first block and stops at the end of its span,
+// as the producers of the next span aren't known before it is committed.
+func (api *API) GetProducerSchedule(ctx context.Context, fromBlock rpc.BlockNumber, count uint64) ([]*ProducerSlot, error) {
+	if count > maxProducerSchedule {
+		count = maxProducerSchedule
+	}
+
+	head := api.chain.CurrentHeader()
+
+	from := head.Number.Uint64() + 1
+	if fromBlock >= 0 {
+		from = uint64(fromBlock)
+	}
+
+	if from == 0 {
+		return nil, errUnknownBlock
+	}
+
+	base := head
+	if from-1 < head.Number.Uint64() {
+		base = api.chain.GetHeaderByNumber(from - 1)
+	}
+
+	if base == nil {
+		return nil, errUnknownBlock
+	}
+
+	snap, err := api.bor.snapshot(api.chain, base.Number.Uint64(), base.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Past the head, the schedule stops at the end of the last committed span.
+	// Otherwise the first block was produced, its span is resolved from heimdall
+	// as the state of base may be pruned.
+	var end uint64
+
+	if base == head {
+		current, err := api.bor.spanner.GetCurrentSpan(ctx, head.Hash())
+		if err != nil {
+			return nil, err
+		}
+
+		end = current.EndBlock
+	} else {
+		heimdallSpan, err := api.spanByBlock(ctx, head, from)
+		if err != nil {
+			return nil, err
+		}
+
+		end = heimdallSpan.EndBlock
+	}
+
+	return producerSchedule(api.bor.config, snap.ValidatorSet, base, from, count, end), nil
+}
+
+// producerSchedule plays the proposer rotation of the validator set forward,
+// from the block following base, the way snapshots do while applying headers.
+func producerSchedule(config *params.BorConfig, validatorSet *valset.ValidatorSet, base *types.Header, from uint64, count uint64, end uint64) []*ProducerSlot {
+	schedule := make([]*ProducerSlot, 0, count)
+
+	validators := validatorSet.Copy()
+	expectedTime := base.Time
+
+	for number := base.Number.Uint64() + 1; number <= end && uint64(len(schedule)) < count; number++ {
+		delay := CalcProducerDelay(number, 0, config)
+		expectedTime += delay
+
+		if number >= from {
+			proposer := validators.GetProposer().Address
+			proposerIndex, _ := validators.GetByAddress(proposer)
+
+			slot := &ProducerSlot{
+				Number:       number,
+				Primary:      proposer,
+				Delay:        delay,
+				ExpectedTime: expectedTime,
+				Backups:      make([]BackupProducer, 0, len(validators.Validators)-1),
+			}
+
+			for succession := 1; succession < len(validators.Validators); succession++ {
+				signer := validators.Validators[(proposerIndex+succession)%len(validators.Validators)].Address
+
+				slot.Backups = append(slot.Backups, BackupProducer{
+					Signer:     signer,
+					Succession: succession,
+					Delay:      CalcProducerDelay(number, succession, config),
+				})
+			}
+
+			schedule = append(schedule, slot)
+		}
+
+		// The producers only change at span boundaries, within a span the
+		// sprint end just rotates the proposer
+		if number > 0 && (number+1)%config.CalculateSprint(number) == 0 {
+			validators = getUpdatedValidatorSet(validators.Copy(), validators.Copy().Validators)
+			validators.IncrementProposerPriority(1)
+		}
+	}
+
+	return schedule
+}
+
+// GetValidatorPerformance returns the block production record of the given
+// validator, or of every tracked validator if none is given.
+func (api *API) GetValidatorPerformance(address *common.Address) ([]*ValidatorPerformance, error) {
+	t := api.bor.livenessTracker.Load()
+	if t == nil {
+		return nil, errLivenessTrackerDisabled
+	}
+
+	if address == nil {
+		return t.Performances(), nil
+	}
+
+	return t.Performances(*address), nil
+}
+
+// GetDoubleSignEvidence returns the evidences of validators sealing two
+// different headers at the same block number, in the given range of blocks.
+func (api *API) GetDoubleSignEvidence(from rpc.BlockNumber, to rpc.BlockNumber) ([]*DoubleSignEvidence, error) {
+	head := api.chain.CurrentHeader().Number.Uint64()
+
+	start, end := head, head
+	if from >= 0 {
+		start = uint64(from)
+	}
+
+	if to >= 0 {
+		end = uint64(to)
+	}
+
+	if start > end {
+		return nil, &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: head}
+	}
+
+	return api.bor.doubleSignDetector.Evidences(start, end), nil
+}
+
+// DoubleSignEvidence creates a subscription sending the evidence of every
+// double sign detected from now on.
+func (api *API) DoubleSignEvidence(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		evidences := make(chan *DoubleSignEvidence, 16)
+		sub := api.bor.doubleSignDetector.SubscribeDoubleSignEvidence(evidences)
+
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case evidence := <-evidences:
+				_ = notifier.Notify(rpcSub.ID, evidence)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// GetStateSyncById returns the record of the state sync with the given id
+// committed in the canonical chain, or nil if it wasn't committed yet.
+//
+// State syncs are only indexed while the node executes the blocks committing
+// them. The blocks imported by a snap sync, and the ones executed before the
+// node maintained the index, aren't indexed: their bor receipts don't hold the
+// payload and L1 transaction of the state syncs. nil is returned for these.
+func (api *API) GetStateSyncById(id uint64) (*types.StateSyncRecord, error) {
+	return rawdb.ReadStateSyncRecord(api.bor.db, id), nil
+}
+
+// GetStateSyncsByRange returns the records of the state syncs with ids in the
+// range [from, to] committed in the canonical chain. The ids which weren't
+// committed yet, or weren't indexed (see GetStateSyncById), are left out.
+func (api *API) GetStateSyncsByRange(from uint64, to uint64) ([]*types.StateSyncRecord, error) {
+	if from > to || to-from >= maxStateSyncRange {
+		return nil, &MaxStateSyncRangeExceededError{From: from, To: to}
+	}
+
+	records := make([]*types.StateSyncRecord, 0)
+
+	for id := from; id <= to; id++ {
+		if record := rawdb.ReadStateSyncRecord(api.bor.db, id); record != nil {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// GetStateSyncByL1TxHash returns the records of the state syncs emitted by the
+// given L1 transaction committed in the canonical chain. A transaction may emit
+// several state syncs, they are returned by ascending id. Only the indexed
+// state syncs are returned, see GetStateSyncById.
+func (api *API) GetStateSyncByL1TxHash(txHash common.Hash) ([]*types.StateSyncRecord, error) {
+	records := make([]*types.StateSyncRecord, 0)
+
+	for _, id := range rawdb.ReadStateSyncIDsByL1TxHash(api.bor.db, txHash) {
+		if record := rawdb.ReadStateSyncRecord(api.bor.db, id); record != nil {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
 // GetRootHash returns the merkle root of the start to end block headers
 func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 	if err := api.initializeRootHashCache(); err != nil {
@@ -313,7 +712,20 @@ func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 	wg.Wait()
 	close(concurrent)
 
-	headers := make([][32]byte, nextPowerOfTwo(length))
+	root, err := ComputeRootHash(blockHeaders)
+	if err != nil {
+		return "", err
+	}
+
+	api.rootHashCache.Add(key, root)
+
+	return root, nil
+}
+
+// ComputeRootHash returns the hex encoded merkle root of the given consecutive
+// block headers, as expected by heimdall for a checkpoint.
+func ComputeRootHash(blockHeaders []*types.Header) (string, error) {
+	headers := make([][32]byte, nextPowerOfTwo(uint64(len(blockHeaders))))
 
 	for i := 0; i < len(blockHeaders); i++ {
 		blockHeader := blockHeaders[i]
@@ -335,10 +747,7 @@ func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 		return "", err
 	}
 
-	root := hex.EncodeToString(tree.Root().Hash)
-	api.rootHashCache.Add(key, root)
-
-	return root, nil
+	return hex.EncodeToString(tree.Root().Hash), nil
 }
 
 func (api *API) initializeRootHashCache() error {
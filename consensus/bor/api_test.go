@@ -0,0 +1,126 @@
+package bor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestProducerSchedule(t *testing.T) {
+	t.Parallel()
+
+	config := &params.BorConfig{
+		Sprint:           map[string]uint64{"0": 4},
+		Period:           map[string]uint64{"0": 2},
+		ProducerDelay:    map[string]uint64{"0": 6},
+		BackupMultiplier: map[string]uint64{"0": 2},
+	}
+
+	validators := []*valset.Validator{
+		{Address: common.HexToAddress("0x1"), VotingPower: 10},
+		{Address: common.HexToAddress("0x2"), VotingPower: 10},
+		{Address: common.HexToAddress("0x3"), VotingPower: 10},
+	}
+	validatorSet := valset.NewValidatorSet(validators)
+
+	base := &types.Header{Number: big.NewInt(5), Time: 100}
+
+	schedule := producerSchedule(config, validatorSet, base, 7, 10, 11)
+
+	// Blocks 7 to 11, the end of the span
+	require.Len(t, schedule, 5)
+	require.Equal(t, uint64(7), schedule[0].Number)
+	require.Equal(t, uint64(11), schedule[4].Number)
+
+	// Block 8 starts a sprint and waits for the producer delay
+	require.Equal(t, uint64(2), schedule[0].Delay)
+	require.Equal(t, uint64(6), schedule[1].Delay)
+	require.Equal(t, uint64(100+2+2+6), schedule[1].ExpectedTime)
+
+	// The proposer only rotates after the sprint ending at block 7
+	rotated := validatorSet.Copy()
+	rotated = getUpdatedValidatorSet(rotated, rotated.Copy().Validators)
+	rotated.IncrementProposerPriority(1)
+
+	require.Equal(t, validatorSet.GetProposer().Address, schedule[0].Primary)
+	require.Equal(t, rotated.GetProposer().Address, schedule[1].Primary)
+	require.NotEqual(t, schedule[0].Primary, schedule[1].Primary)
+	require.Equal(t, schedule[1].Primary, schedule[4].Primary)
+
+	for _, slot := range schedule {
+		require.Len(t, slot.Backups, 2)
+		require.NotContains(t, []common.Address{slot.Backups[0].Signer, slot.Backups[1].Signer}, slot.Primary)
+		require.Equal(t, 1, slot.Backups[0].Succession)
+		require.Equal(t, CalcProducerDelay(slot.Number, 2, config), slot.Backups[1].Delay)
+	}
+
+	// The count limits the schedule
+	require.Len(t, producerSchedule(config, validatorSet, base, 6, 2, 11), 2)
+}
+
+// spansHeimdallClient serves a fixed list of spans, counting the requests.
+type spansHeimdallClient struct {
+	IHeimdallClient
+
+	spans    []*span.HeimdallSpan
+	requests int
+}
+
+func (h *spansHeimdallClient) Span(_ context.Context, id uint64) (*span.HeimdallSpan, error) {
+	h.requests++
+
+	if id >= uint64(len(h.spans)) {
+		return nil, fmt.Errorf("span %d not found", id)
+	}
+
+	return h.spans[id], nil
+}
+
+func TestSpanByBlock(t *testing.T) {
+	t.Parallel()
+
+	// The first span is shorter than the following ones
+	heimdall := &spansHeimdallClient{
+		spans: []*span.HeimdallSpan{{Span: span.Span{ID: 0, StartBlock: 0, EndBlock: 255}}},
+	}
+
+	for id := uint64(1); id <= 100; id++ {
+		start := heimdall.spans[id-1].EndBlock + 1
+		heimdall.spans = append(heimdall.spans, &span.HeimdallSpan{Span: span.Span{ID: id, StartBlock: start, EndBlock: start + 6399}})
+	}
+
+	// The head is in span 99, only its state is available
+	head := &types.Header{Number: big.NewInt(int64(heimdall.spans[99].StartBlock + 10))}
+
+	spanner := NewMockSpanner(gomock.NewController(t))
+	spanner.EXPECT().GetCurrentSpan(gomock.Any(), head.Hash()).Return(&heimdall.spans[99].Span, nil).AnyTimes()
+
+	api := &API{bor: &Bor{HeimdallClient: heimdall, spanner: spanner}}
+
+	for _, id := range []uint64{0, 1, 2, 50, 98, 99, 100} {
+		for _, number := range []uint64{heimdall.spans[id].StartBlock, heimdall.spans[id].EndBlock} {
+			heimdall.requests = 0
+
+			heimdallSpan, err := api.spanByBlock(context.Background(), head, number)
+			require.NoError(t, err, "block %d", number)
+			require.Equal(t, id, heimdallSpan.ID, "block %d", number)
+
+			// Past spans are binary searched
+			require.LessOrEqual(t, heimdall.requests, 8, "block %d", number)
+		}
+	}
+
+	// Blocks of spans unknown to heimdall
+	_, err := api.spanByBlock(context.Background(), head, heimdall.spans[100].EndBlock+1)
+	require.Error(t, err)
+}
@@ -223,6 +223,10 @@ type Bor struct {
 	GenesisContractsClient GenesisContract
 	HeimdallClient         IHeimdallClient
 
+	livenessTracker    atomic.Pointer[LivenessTracker]
+	doubleSignDetector *DoubleSignDetector
+	slashingProtection *SlashingProtection
+
 	// The fields below are for testing only
 	fakeDiff      bool // Skip difficulty verifications
 	devFakeAuthor bool
@@ -269,6 +273,9 @@ func New(
 		devFakeAuthor:          devFakeAuthor,
 	}
 
+	c.doubleSignDetector = newDoubleSignDetector(borConfig, signatures, db)
+	c.slashingProtection = NewSlashingProtection(borConfig, db)
+
 	c.authorizedSigner.Store(&signer{
 		common.Address{},
 		func(_ accounts.Account, _ string, i []byte) ([]byte, error) {
@@ -851,9 +858,11 @@ func (c *Bor) Finalize(chain consensus.ChainHeaderReader, header *types.Header,
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
 	header.UncleHash = types.CalcUncleHash(nil)
 
-	// Set state sync data to blockchain
+	// Set state sync data to blockchain, and to the state of the block which
+	// carries it until the block is written
 	bc := chain.(*core.BlockChain)
 	bc.SetStateSync(stateSyncData)
+	state.SetStateSyncs(stateSyncData)
 }
 
 func decodeGenesisAlloc(i interface{}) (core.GenesisAlloc, error) {
@@ -951,9 +960,11 @@ func (c *Bor) FinalizeAndAssemble(ctx context.Context, chain consensus.ChainHead
 	// Assemble block
 	block := types.NewBlock(header, txs, nil, receipts, trie.NewStackTrie(nil))
 
-	// set state sync
+	// set state sync on the chain, and on the state of the block which carries
+	// it until the block is written
 	bc := chain.(core.BorStateSyncer)
 	bc.SetStateSync(stateSyncData)
+	state.SetStateSyncs(stateSyncData)
 
 	tracing.SetAttributes(
 		finalizeSpan,
@@ -1026,12 +1037,6 @@ func (c *Bor) Seal(ctx context.Context, chain consensus.ChainHeaderReader, block
 	// wiggle was already accounted for in header.Time, this is just for logging
 	wiggle := time.Duration(successionNumber) * time.Duration(c.config.CalculateBackupMultiplier(number)) * time.Second
 
-	// Sign all the things!
-	err = Sign(currentSigner.signFn, currentSigner.signer, header, c.config)
-	if err != nil {
-		return err
-	}
-
 	// Wait until sealing is terminated or delay timeout.
 	log.Info("Waiting for slot to sign and propagate", "number", number, "hash", header.Hash, "delay-in-sec", uint(delay), "delay", common.PrettyDuration(delay))
 
@@ -1039,8 +1044,28 @@ func (c *Bor) Seal(ctx context.Context, chain consensus.ChainHeaderReader, block
 		select {
 		case <-stop:
 			log.Debug("Discarding sealing operation for block", "number", number)
+			tracing.EndSpan(sealSpan)
+
 			return
 		case <-time.After(delay):
+			// Never sign two different headers at the same height, even across
+			// restarts. Only the headers about to be published are recorded, a
+			// discarded seal doesn't use up the height.
+			if err := c.slashingProtection.check(currentSigner.signer, header); err != nil {
+				log.Error("Refused to seal block", "number", number, "err", err)
+				tracing.EndSpan(sealSpan)
+
+				return
+			}
+
+			// Sign all the things!
+			if err := Sign(currentSigner.signFn, currentSigner.signer, header, c.config); err != nil {
+				log.Error("Failed to sign block", "number", number, "err", err)
+				tracing.EndSpan(sealSpan)
+
+				return
+			}
+
 			if wiggle > 0 {
 				log.Info(
 					"Sealing out-of-turn",
@@ -1106,6 +1131,11 @@ func (c *Bor) CalcDifficulty(chain consensus.ChainHeaderReader, _ uint64, parent
 	return new(big.Int).SetUint64(Difficulty(snap.ValidatorSet, c.authorizedSigner.Load().signer))
 }
 
+// SlashingProtection returns the records of the headers sealed by the node.
+func (c *Bor) SlashingProtection() *SlashingProtection {
+	return c.slashingProtection
+}
+
 // SealHash returns the hash of a block prior to it being sealed.
 func (c *Bor) SealHash(header *types.Header) common.Hash {
 	return SealHash(header, c.config)
@@ -1122,12 +1152,18 @@ func (c *Bor) APIs(chain consensus.ChainHeaderReader) []rpc.API {
 	}}
 }
 
-// Close implements consensus.Engine. It's a noop for bor as there are no background threads.
+// Close implements consensus.Engine, stopping the background services of bor.
 func (c *Bor) Close() error {
 	c.closeOnce.Do(func() {
 		if c.HeimdallClient != nil {
 			c.HeimdallClient.Close()
 		}
+
+		if t := c.livenessTracker.Load(); t != nil {
+			t.stop()
+		}
+
+		c.doubleSignDetector.close()
 	})
 
 	return nil
@@ -1220,33 +1256,12 @@ func (c *Bor) CommitStates(
 	fetchStart := time.Now()
 	number := header.Number.Uint64()
 
-	var (
-		lastStateIDBig *big.Int
-		from           uint64
-		to             time.Time
-		err            error
-	)
-
-	if c.config.IsIndore(header.Number) {
-		// Fetch the LastStateId from contract via current state instance
-		lastStateIDBig, err = c.GenesisContractsClient.LastStateId(state.Copy(), number-1, header.ParentHash)
-		if err != nil {
-			return nil, err
-		}
-
-		stateSyncDelay := c.config.CalculateStateSyncDelay(number)
-		to = time.Unix(int64(header.Time-stateSyncDelay), 0)
-	} else {
-		lastStateIDBig, err = c.GenesisContractsClient.LastStateId(nil, number-1, header.ParentHash)
-		if err != nil {
-			return nil, err
-		}
-
-		to = time.Unix(int64(chain.Chain.GetHeaderByNumber(number-c.config.CalculateSprint(number)).Time), 0)
+	lastStateID, to, err := c.stateSyncWindow(state, header, chain)
+	if err != nil {
+		return nil, err
 	}
 
-	lastStateID := lastStateIDBig.Uint64()
-	from = lastStateID + 1
+	from := lastStateID + 1
 
 	log.Info(
 		"Fetching state updates from Heimdall",
@@ -1269,14 +1284,20 @@ func (c *Bor) CommitStates(
 	totalGas := 0 /// limit on gas for state sync per block
 	chainID := c.chainConfig.ChainID.String()
 	stateSyncs := make([]*types.StateSyncData, 0, len(eventRecords))
+	budget := c.newStateSyncBudget(header.Number)
 
 	var gasUsed uint64
 
-	for _, eventRecord := range eventRecords {
+	for i, eventRecord := range eventRecords {
 		if eventRecord.ID <= lastStateID {
 			continue
 		}
 
+		if budget.exhausted() {
+			log.Info("State sync budget exhausted, carrying over to the next sprint", "block", number, "committed", len(stateSyncs), "gas", totalGas, "remaining", len(eventRecords)-i)
+			break
+		}
+
 		if err = validateEventRecord(eventRecord, number, to, lastStateID, chainID); err != nil {
 			log.Error("while validating event record", "block", number, "to", to, "stateID", lastStateID+1, "error", err.Error())
 			break
@@ -1287,6 +1308,7 @@ func (c *Bor) CommitStates(
 			Contract: eventRecord.Contract,
 			Data:     hex.EncodeToString(eventRecord.Data),
 			TxHash:   eventRecord.TxHash,
+			LogIndex: uint(len(state.Logs())),
 		}
 
 		stateSyncs = append(stateSyncs, &stateData)
@@ -1300,6 +1322,7 @@ func (c *Bor) CommitStates(
 		}
 
 		totalGas += int(gasUsed)
+		budget.spend(gasUsed)
 
 		lastStateID++
 	}
@@ -1311,6 +1334,32 @@ func (c *Bor) CommitStates(
 	return stateSyncs, nil
 }
 
+// stateSyncWindow returns the id of the last state sync committed before the
+// block, and the time before which the next ones must have been emitted to be
+// committed in the block.
+func (c *Bor) stateSyncWindow(state *state.StateDB, header *types.Header, chain statefull.ChainContext) (uint64, time.Time, error) {
+	number := header.Number.Uint64()
+
+	if c.config.IsIndore(header.Number) {
+		// Fetch the LastStateId from contract via current state instance
+		lastStateIDBig, err := c.GenesisContractsClient.LastStateId(state.Copy(), number-1, header.ParentHash)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+
+		stateSyncDelay := c.config.CalculateStateSyncDelay(number)
+
+		return lastStateIDBig.Uint64(), time.Unix(int64(header.Time-stateSyncDelay), 0), nil
+	}
+
+	lastStateIDBig, err := c.GenesisContractsClient.LastStateId(nil, number-1, header.ParentHash)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return lastStateIDBig.Uint64(), time.Unix(int64(chain.Chain.GetHeaderByNumber(number-c.config.CalculateSprint(number)).Time), 0), nil
+}
+
 func validateEventRecord(eventRecord *clerk.EventRecordWithTime, number uint64, to time.Time, lastStateID uint64, chainID string) error {
 	// event id should be sequential and event.Time should lie in the range [from, to)
 	if lastStateID+1 != eventRecord.ID || eventRecord.ChainID != chainID || !eventRecord.Time.Before(to) {
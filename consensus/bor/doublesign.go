@@ -0,0 +1,164 @@
+package bor
+
+import (
+	"encoding/json"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// seenSignedHeaders is the number of (signer, number) pairs remembered to
+// detect a second header sealed by the same signer.
+const seenSignedHeaders = 8192
+
+var doubleSignMeter = metrics.NewRegisteredMeter("bor/doublesign", nil)
+
+// DoubleSignEvidence proves that a signer sealed two different headers at the
+// same block number. Headers sealed on different parents may be the result of
+// a reorg, headers sharing their parent are a plain equivocation.
+type DoubleSignEvidence struct {
+	Signer     common.Address `json:"signer"`
+	Number     uint64         `json:"number"`
+	First      *types.Header  `json:"first"`
+	Second     *types.Header  `json:"second"`
+	SameParent bool           `json:"sameParent"`
+}
+
+type signedNumber struct {
+	signer common.Address
+	number uint64
+}
+
+// DoubleSignDetector watches the headers received from the network for signers
+// sealing more than one header at the same block number.
+type DoubleSignDetector struct {
+	config     *params.BorConfig
+	signatures *lru.ARCCache
+	db         ethdb.Database
+
+	lock sync.Mutex
+	seen *lru.Cache // signedNumber -> first header seen
+
+	feed  event.Feed
+	scope event.SubscriptionScope
+}
+
+func newDoubleSignDetector(config *params.BorConfig, signatures *lru.ARCCache, db ethdb.Database) *DoubleSignDetector {
+	seen, _ := lru.New(seenSignedHeaders)
+
+	return &DoubleSignDetector{
+		config:     config,
+		signatures: signatures,
+		db:         db,
+		seen:       seen,
+	}
+}
+
+// DoubleSignDetector returns the detector the network handlers report the
+// received headers to.
+func (c *Bor) DoubleSignDetector() *DoubleSignDetector {
+	return c.doubleSignDetector
+}
+
+// Observe checks the headers against the ones seen before. Evidence of a double
+// sign is stored and sent to the subscribers.
+func (d *DoubleSignDetector) Observe(headers ...*types.Header) {
+	for _, header := range headers {
+		if header.Number == nil || header.Number.Sign() == 0 {
+			continue
+		}
+
+		// Headers are not verified yet, those without a valid seal are ignored
+		signer, err := ecrecover(header, d.signatures, d.config)
+		if err != nil {
+			continue
+		}
+
+		if evidence := d.check(signer, header); evidence != nil {
+			d.report(evidence)
+		}
+	}
+}
+
+func (d *DoubleSignDetector) check(signer common.Address, header *types.Header) *DoubleSignEvidence {
+	key := signedNumber{signer: signer, number: header.Number.Uint64()}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	previous, ok := d.seen.Get(key)
+	if !ok {
+		d.seen.Add(key, header)
+		return nil
+	}
+
+	first := previous.(*types.Header)
+	if first.Hash() == header.Hash() {
+		return nil
+	}
+
+	return &DoubleSignEvidence{
+		Signer:     signer,
+		Number:     key.number,
+		First:      first,
+		Second:     header,
+		SameParent: first.ParentHash == header.ParentHash,
+	}
+}
+
+func (d *DoubleSignDetector) report(evidence *DoubleSignEvidence) {
+	// Only the first conflicting pair is kept, any other one proves the same
+	if rawdb.HasDoubleSignEvidence(d.db, evidence.Number, evidence.Signer) {
+		return
+	}
+
+	doubleSignMeter.Mark(1)
+
+	log.Warn("Detected validator double sign", "signer", evidence.Signer, "number", evidence.Number,
+		"first", evidence.First.Hash(), "second", evidence.Second.Hash(), "sameParent", evidence.SameParent)
+
+	if data, err := json.Marshal(evidence); err != nil {
+		log.Error("Failed to encode double sign evidence", "err", err)
+	} else if err := rawdb.WriteDoubleSignEvidence(d.db, evidence.Number, evidence.Signer, data); err != nil {
+		log.Error("Failed to store double sign evidence", "err", err)
+	}
+
+	d.feed.Send(evidence)
+}
+
+// Evidences returns the stored evidences of double signs in the blocks from
+// from to to, inclusive.
+func (d *DoubleSignDetector) Evidences(from uint64, to uint64) []*DoubleSignEvidence {
+	evidences := make([]*DoubleSignEvidence, 0)
+
+	for _, data := range rawdb.ReadDoubleSignEvidences(d.db, from, to) {
+		evidence := new(DoubleSignEvidence)
+		if err := json.Unmarshal(data, evidence); err != nil {
+			log.Warn("Discarding invalid double sign evidence", "err", err)
+			continue
+		}
+
+		evidences = append(evidences, evidence)
+	}
+
+	return evidences
+}
+
+// SubscribeDoubleSignEvidence registers a subscription for the evidences of
+// double signs detected from now on.
+func (d *DoubleSignDetector) SubscribeDoubleSignEvidence(ch chan<- *DoubleSignEvidence) event.Subscription {
+	return d.scope.Track(d.feed.Subscribe(ch))
+}
+
+func (d *DoubleSignDetector) close() {
+	d.scope.Close()
+}
@@ -0,0 +1,81 @@
+package bor
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func signedHeader(t *testing.T, key *ecdsa.PrivateKey, config *params.BorConfig, number int64, parent common.Hash, time uint64) *types.Header {
+	t.Helper()
+
+	header := &types.Header{
+		Number:     big.NewInt(number),
+		Difficulty: big.NewInt(1),
+		ParentHash: parent,
+		Time:       time,
+		Extra:      make([]byte, types.ExtraVanityLength+types.ExtraSealLength),
+	}
+
+	signFn := func(_ accounts.Account, _ string, data []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(data), key)
+	}
+
+	require.NoError(t, Sign(signFn, crypto.PubkeyToAddress(key.PublicKey), header, config))
+
+	return header
+}
+
+func TestDoubleSignDetector(t *testing.T) {
+	t.Parallel()
+
+	var (
+		config    = &params.BorConfig{}
+		db        = rawdb.NewMemoryDatabase()
+		sigs, _   = lru.NewARC(16)
+		detector  = newDoubleSignDetector(config, sigs, db)
+		key, _    = crypto.GenerateKey()
+		other, _  = crypto.GenerateKey()
+		signer    = crypto.PubkeyToAddress(key.PublicKey)
+		evidences = make(chan *DoubleSignEvidence, 4)
+	)
+
+	sub := detector.SubscribeDoubleSignEvidence(evidences)
+	defer sub.Unsubscribe()
+
+	parent := common.HexToHash("0x01")
+	first := signedHeader(t, key, config, 10, parent, 100)
+
+	// Seeing the same header again, or another signer at the same height, is fine
+	detector.Observe(first, first, signedHeader(t, other, config, 10, parent, 102))
+	require.Empty(t, detector.Evidences(0, 100))
+
+	second := signedHeader(t, key, config, 10, parent, 101)
+	detector.Observe(second)
+
+	evidence := <-evidences
+	require.Equal(t, signer, evidence.Signer)
+	require.Equal(t, uint64(10), evidence.Number)
+	require.Equal(t, first.Hash(), evidence.First.Hash())
+	require.Equal(t, second.Hash(), evidence.Second.Hash())
+	require.True(t, evidence.SameParent)
+
+	// The evidence is stored once
+	detector.Observe(signedHeader(t, key, config, 10, common.HexToHash("0x02"), 103))
+
+	stored := detector.Evidences(0, 100)
+	require.Len(t, stored, 1)
+	require.Equal(t, second.Hash(), stored[0].Second.Hash())
+	require.Empty(t, detector.Evidences(11, 100))
+	require.Len(t, evidences, 0)
+}
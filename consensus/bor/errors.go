@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
 )
 
@@ -21,6 +22,47 @@ func (e *MaxCheckpointLengthExceededError) Error() string {
 	)
 }
 
+// MaxStateSyncRangeExceededError is returned if a range of state sync ids is
+// reversed or larger than allowed
+type MaxStateSyncRangeExceededError struct {
+	From uint64
+	To   uint64
+}
+
+func (e *MaxStateSyncRangeExceededError) Error() string {
+	return fmt.Sprintf(
+		"From: %d and to state sync id: %d must form a range of at most %d ids",
+		e.From,
+		e.To,
+		maxStateSyncRange,
+	)
+}
+
+// SpanNotFoundError is returned if no known span contains a block
+type SpanNotFoundError struct {
+	Number uint64
+}
+
+func (e *SpanNotFoundError) Error() string {
+	return fmt.Sprintf("No span found for block %d", e.Number)
+}
+
+// MaxValidatorSetHistoryExceededError is returned if the range of blocks of a
+// validator set history request spans too many spans
+type MaxValidatorSetHistoryExceededError struct {
+	Start uint64
+	End   uint64
+}
+
+func (e *MaxValidatorSetHistoryExceededError) Error() string {
+	return fmt.Sprintf(
+		"Start: %d and end block: %d span more than the max allowed number of spans: %d",
+		e.Start,
+		e.End,
+		maxValidatorSetHistory,
+	)
+}
+
 // MismatchingValidatorsError is returned if a last block in sprint contains a
 // list of validators different from the one that local node calculated
 type MismatchingValidatorsError struct {
@@ -38,6 +80,25 @@ func (e *MismatchingValidatorsError) Error() string {
 	)
 }
 
+// SlashingProtectionError is returned when asked to seal a header at a block
+// number where a different header was sealed before.
+type SlashingProtectionError struct {
+	Number    uint64
+	Signer    common.Address
+	Sealed    common.Hash
+	Requested common.Hash
+}
+
+func (e *SlashingProtectionError) Error() string {
+	return fmt.Sprintf(
+		"Refusing to seal block %d by %s: header %x already sealed, requested %x",
+		e.Number,
+		e.Signer.Hex(),
+		e.Sealed,
+		e.Requested,
+	)
+}
+
 type BlockTooSoonError struct {
 	Number     uint64
 	Succession int
@@ -30,10 +30,11 @@ func (h *HeimdallAppClient) FetchCheckpoint(_ context.Context, number int64) (*c
 
 	log.Info("Fetched checkpoint", "number", number)
 
-	return toBorCheckpoint(res), nil
+	return ToBorCheckpoint(res), nil
 }
 
-func toBorCheckpoint(hdCheckpoint hmTypes.Checkpoint) *checkpoint.Checkpoint {
+// ToBorCheckpoint converts a heimdall checkpoint into its bor counterpart.
+func ToBorCheckpoint(hdCheckpoint hmTypes.Checkpoint) *checkpoint.Checkpoint {
 	return &checkpoint.Checkpoint{
 		Proposer:   hdCheckpoint.Proposer.EthAddress(),
 		StartBlock: big.NewInt(int64(hdCheckpoint.StartBlock)),
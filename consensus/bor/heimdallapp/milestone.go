@@ -33,7 +33,7 @@ func (h *HeimdallAppClient) FetchMilestone(_ context.Context) (*milestone.Milest
 
 	log.Info("Fetched Latest Milestone")
 
-	return toBorMilestone(res), nil
+	return ToBorMilestone(res), nil
 }
 
 func (h *HeimdallAppClient) FetchNoAckMilestone(_ context.Context, milestoneID string) error {
@@ -70,13 +70,15 @@ func (h *HeimdallAppClient) FetchMilestoneID(_ context.Context, milestoneID stri
 	return fmt.Errorf("Milestone corresponding to Milestone ID:%v doesn't exist in Heimdall", milestoneID)
 }
 
-func toBorMilestone(hdMilestone *hmTypes.Milestone) *milestone.Milestone {
+// ToBorMilestone converts a heimdall milestone into its bor counterpart.
+func ToBorMilestone(hdMilestone *hmTypes.Milestone) *milestone.Milestone {
 	return &milestone.Milestone{
-		Proposer:   hdMilestone.Proposer.EthAddress(),
-		StartBlock: big.NewInt(int64(hdMilestone.StartBlock)),
-		EndBlock:   big.NewInt(int64(hdMilestone.EndBlock)),
-		Hash:       hdMilestone.Hash.EthHash(),
-		BorChainID: hdMilestone.BorChainID,
-		Timestamp:  hdMilestone.TimeStamp,
+		Proposer:    hdMilestone.Proposer.EthAddress(),
+		StartBlock:  big.NewInt(int64(hdMilestone.StartBlock)),
+		EndBlock:    big.NewInt(int64(hdMilestone.EndBlock)),
+		Hash:        hdMilestone.Hash.EthHash(),
+		BorChainID:  hdMilestone.BorChainID,
+		MilestoneID: hdMilestone.MilestoneID,
+		Timestamp:   hdMilestone.TimeStamp,
 	}
 }
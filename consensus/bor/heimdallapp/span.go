@@ -20,10 +20,11 @@ func (h *HeimdallAppClient) Span(ctx context.Context, spanID uint64) (*span.Heim
 
 	log.Info("Fetched span", "spanID", spanID)
 
-	return toSpan(res), nil
+	return ToSpan(res), nil
 }
 
-func toSpan(hdSpan *hmTypes.Span) *span.HeimdallSpan {
+// ToSpan converts a span of the heimdall bor module into its bor counterpart.
+func ToSpan(hdSpan *hmTypes.Span) *span.HeimdallSpan {
 	return &span.HeimdallSpan{
 		Span: span.Span{
 			ID:         hdSpan.ID,
@@ -0,0 +1,241 @@
+package heimdallcache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	spanHitMeter        = metrics.NewRegisteredMeter("client/cache/span/hit", nil)
+	spanMissMeter       = metrics.NewRegisteredMeter("client/cache/span/miss", nil)
+	stateSyncHitMeter   = metrics.NewRegisteredMeter("client/cache/statesync/hit", nil)
+	stateSyncMissMeter  = metrics.NewRegisteredMeter("client/cache/statesync/miss", nil)
+	checkpointHitMeter  = metrics.NewRegisteredMeter("client/cache/checkpoint/hit", nil)
+	checkpointMissMeter = metrics.NewRegisteredMeter("client/cache/checkpoint/miss", nil)
+)
+
+var _ bor.IHeimdallClient = (*HeimdallCacheClient)(nil)
+
+// HeimdallCacheClient wraps a heimdall client and persists the immutable
+// responses (spans, state-sync events and checkpoints) in the chain database,
+// so repeated lookups, e.g. while re-importing historical blocks, are served
+// from disk without reaching heimdall.
+type HeimdallCacheClient struct {
+	client bor.IHeimdallClient
+	db     ethdb.KeyValueStore
+
+	eventsLock sync.Mutex // Serializes updates of the cached state-sync event range
+}
+
+// NewHeimdallCacheClient creates a caching heimdall client on top of client.
+func NewHeimdallCacheClient(client bor.IHeimdallClient, db ethdb.KeyValueStore) *HeimdallCacheClient {
+	return &HeimdallCacheClient{
+		client: client,
+		db:     db,
+	}
+}
+
+// Span returns the span from disk if known, otherwise it is fetched from
+// heimdall and stored.
+func (h *HeimdallCacheClient) Span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	if data := rawdb.ReadHeimdallSpan(h.db, spanID); len(data) > 0 {
+		var heimdallSpan span.HeimdallSpan
+		if err := json.Unmarshal(data, &heimdallSpan); err == nil {
+			spanHitMeter.Mark(1)
+			return &heimdallSpan, nil
+		}
+
+		log.Warn("Discarding invalid cached heimdall span", "spanID", spanID)
+	}
+
+	spanMissMeter.Mark(1)
+
+	heimdallSpan, err := h.client.Span(ctx, spanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(heimdallSpan); err != nil {
+		log.Warn("Failed to encode heimdall span for caching", "spanID", spanID, "err", err)
+	} else if err := rawdb.WriteHeimdallSpan(h.db, spanID, data); err != nil {
+		log.Warn("Failed to cache heimdall span", "spanID", spanID, "err", err)
+	}
+
+	return heimdallSpan, nil
+}
+
+// StateSyncEvents returns the events from disk when the requested range is
+// known to be completely cached, otherwise they are fetched from heimdall and
+// the cached range is extended.
+func (h *HeimdallCacheClient) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	if events, ok := h.cachedEvents(fromID, to); ok {
+		stateSyncHitMeter.Mark(1)
+		return events, nil
+	}
+
+	stateSyncMissMeter.Mark(1)
+
+	events, err := h.client.StateSyncEvents(ctx, fromID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	h.storeEvents(fromID, to, events)
+
+	return events, nil
+}
+
+func (h *HeimdallCacheClient) cachedEvents(fromID uint64, to int64) ([]*clerk.EventRecordWithTime, bool) {
+	h.eventsLock.Lock()
+	defer h.eventsLock.Unlock()
+
+	coverage := rawdb.ReadHeimdallEventCoverage(h.db)
+	if coverage == nil || fromID < coverage.FromID || to > coverage.To {
+		return nil, false
+	}
+
+	events := make([]*clerk.EventRecordWithTime, 0)
+
+	for id := fromID; id < coverage.NextID; id++ {
+		data := rawdb.ReadHeimdallEvent(h.db, id)
+		if len(data) == 0 {
+			return nil, false
+		}
+
+		event := new(clerk.EventRecordWithTime)
+		if err := json.Unmarshal(data, event); err != nil {
+			log.Warn("Discarding invalid cached state-sync event", "id", id, "err", err)
+			return nil, false
+		}
+
+		// Event times are increasing with their ids, nothing after this one can be in range
+		if event.Time.Unix() >= to {
+			break
+		}
+
+		events = append(events, event)
+	}
+
+	return events, true
+}
+
+func (h *HeimdallCacheClient) storeEvents(fromID uint64, to int64, events []*clerk.EventRecordWithTime) {
+	h.eventsLock.Lock()
+	defer h.eventsLock.Unlock()
+
+	nextID := fromID
+
+	for _, event := range events {
+		// Only a gapless sequence of events can extend the cached range
+		if event.ID != nextID {
+			log.Debug("Not caching non sequential state-sync events", "expected", nextID, "got", event.ID)
+			return
+		}
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			log.Warn("Failed to encode state-sync event for caching", "id", event.ID, "err", err)
+			return
+		}
+
+		if err := rawdb.WriteHeimdallEvent(h.db, event.ID, data); err != nil {
+			log.Warn("Failed to cache state-sync event", "id", event.ID, "err", err)
+			return
+		}
+
+		nextID++
+	}
+
+	coverage := rawdb.ReadHeimdallEventCoverage(h.db)
+
+	// Every event below fromID is already cached if the previous range reaches
+	// it, so the ranges can be merged. Otherwise start a new range.
+	if coverage != nil && coverage.FromID <= fromID && fromID <= coverage.NextID {
+		if nextID > coverage.NextID {
+			coverage.NextID = nextID
+		}
+
+		if to > coverage.To {
+			coverage.To = to
+		}
+	} else {
+		coverage = &rawdb.HeimdallEventCoverage{FromID: fromID, NextID: nextID, To: to}
+	}
+
+	if err := rawdb.WriteHeimdallEventCoverage(h.db, coverage); err != nil {
+		log.Warn("Failed to store cached state-sync event range", "err", err)
+	}
+}
+
+// FetchCheckpoint returns the checkpoint from disk if known, otherwise it is
+// fetched from heimdall and stored. The latest checkpoint (number -1) is always
+// fetched from heimdall.
+func (h *HeimdallCacheClient) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	if number < 0 {
+		return h.client.FetchCheckpoint(ctx, number)
+	}
+
+	if data := rawdb.ReadHeimdallCheckpoint(h.db, uint64(number)); len(data) > 0 {
+		var cp checkpoint.Checkpoint
+		if err := json.Unmarshal(data, &cp); err == nil {
+			checkpointHitMeter.Mark(1)
+			return &cp, nil
+		}
+
+		log.Warn("Discarding invalid cached checkpoint", "number", number)
+	}
+
+	checkpointMissMeter.Mark(1)
+
+	cp, err := h.client.FetchCheckpoint(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(cp); err != nil {
+		log.Warn("Failed to encode checkpoint for caching", "number", number, "err", err)
+	} else if err := rawdb.WriteHeimdallCheckpoint(h.db, uint64(number), data); err != nil {
+		log.Warn("Failed to cache checkpoint", "number", number, "err", err)
+	}
+
+	return cp, nil
+}
+
+func (h *HeimdallCacheClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	return h.client.FetchCheckpointCount(ctx)
+}
+
+func (h *HeimdallCacheClient) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	return h.client.FetchMilestone(ctx)
+}
+
+func (h *HeimdallCacheClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	return h.client.FetchMilestoneCount(ctx)
+}
+
+func (h *HeimdallCacheClient) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	return h.client.FetchNoAckMilestone(ctx, milestoneID)
+}
+
+func (h *HeimdallCacheClient) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	return h.client.FetchLastNoAckMilestone(ctx)
+}
+
+func (h *HeimdallCacheClient) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	return h.client.FetchMilestoneID(ctx, milestoneID)
+}
+
+func (h *HeimdallCacheClient) Close() {
+	h.client.Close()
+}
@@ -0,0 +1,172 @@
+package heimdallcache
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errHeimdallDown = errors.New("heimdall down")
+
+// fakeHeimdall counts the requests reaching heimdall and can be switched off
+// to emulate an unreachable heimdall.
+type fakeHeimdall struct {
+	events   []*clerk.EventRecordWithTime
+	down     bool
+	requests int
+}
+
+func (f *fakeHeimdall) StateSyncEvents(_ context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	f.requests++
+
+	if f.down {
+		return nil, errHeimdallDown
+	}
+
+	events := make([]*clerk.EventRecordWithTime, 0)
+
+	for _, event := range f.events {
+		if event.ID >= fromID && event.Time.Unix() < to {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (f *fakeHeimdall) Span(_ context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	f.requests++
+
+	if f.down {
+		return nil, errHeimdallDown
+	}
+
+	return &span.HeimdallSpan{Span: span.Span{ID: spanID, StartBlock: spanID * 10, EndBlock: spanID*10 + 9}, ChainID: "15001"}, nil
+}
+
+func (f *fakeHeimdall) FetchCheckpoint(_ context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	f.requests++
+
+	if f.down {
+		return nil, errHeimdallDown
+	}
+
+	return &checkpoint.Checkpoint{StartBlock: big.NewInt(number * 10), EndBlock: big.NewInt(number*10 + 9)}, nil
+}
+
+func (f *fakeHeimdall) FetchCheckpointCount(context.Context) (int64, error) { return 0, nil }
+func (f *fakeHeimdall) FetchMilestone(context.Context) (*milestone.Milestone, error) {
+	return nil, nil
+}
+func (f *fakeHeimdall) FetchMilestoneCount(context.Context) (int64, error) { return 0, nil }
+func (f *fakeHeimdall) FetchNoAckMilestone(context.Context, string) error  { return nil }
+func (f *fakeHeimdall) FetchLastNoAckMilestone(context.Context) (string, error) {
+	return "", nil
+}
+func (f *fakeHeimdall) FetchMilestoneID(context.Context, string) error { return nil }
+func (f *fakeHeimdall) Close()                                         {}
+
+func newEvent(id uint64, at int64) *clerk.EventRecordWithTime {
+	return &clerk.EventRecordWithTime{EventRecord: clerk.EventRecord{ID: id, ChainID: "15001", Data: []byte{byte(id)}}, Time: time.Unix(at, 0).UTC()}
+}
+
+func TestCacheSpan(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeHeimdall{}
+	client := NewHeimdallCacheClient(fake, rawdb.NewMemoryDatabase())
+
+	first, err := client.Span(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, 1, fake.requests)
+
+	fake.down = true
+
+	second, err := client.Span(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+	require.Equal(t, 1, fake.requests)
+
+	_, err = client.Span(context.Background(), 4)
+	require.ErrorIs(t, err, errHeimdallDown)
+}
+
+func TestCacheCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeHeimdall{}
+	client := NewHeimdallCacheClient(fake, rawdb.NewMemoryDatabase())
+
+	_, err := client.FetchCheckpoint(context.Background(), 2)
+	require.NoError(t, err)
+
+	fake.down = true
+
+	cp, err := client.FetchCheckpoint(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(29), cp.EndBlock.Int64())
+
+	_, err = client.FetchCheckpoint(context.Background(), -1)
+	require.ErrorIs(t, err, errHeimdallDown)
+}
+
+func TestCacheStateSyncEvents(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeHeimdall{
+		events: []*clerk.EventRecordWithTime{newEvent(1, 10), newEvent(2, 20), newEvent(3, 30), newEvent(4, 40)},
+	}
+	client := NewHeimdallCacheClient(fake, rawdb.NewMemoryDatabase())
+
+	events, err := client.StateSyncEvents(context.Background(), 1, 25)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	events, err = client.StateSyncEvents(context.Background(), 3, 35)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, 2, fake.requests)
+
+	fake.down = true
+
+	// Everything up to id 3 and time 35 is cached, any sub range is served from disk
+	events, err = client.StateSyncEvents(context.Background(), 1, 35)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, fake.events[:3], events)
+
+	events, err = client.StateSyncEvents(context.Background(), 2, 15)
+	require.NoError(t, err)
+	require.Len(t, events, 0)
+
+	// Beyond the cached range heimdall has to be asked
+	_, err = client.StateSyncEvents(context.Background(), 3, 45)
+	require.ErrorIs(t, err, errHeimdallDown)
+	require.Equal(t, 3, fake.requests)
+}
+
+func TestCacheStateSyncEventsGap(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeHeimdall{
+		events: []*clerk.EventRecordWithTime{newEvent(1, 10), newEvent(3, 30)},
+	}
+	client := NewHeimdallCacheClient(fake, rawdb.NewMemoryDatabase())
+
+	_, err := client.StateSyncEvents(context.Background(), 1, 35)
+	require.NoError(t, err)
+
+	_, err = client.StateSyncEvents(context.Background(), 1, 35)
+	require.NoError(t, err)
+	require.Equal(t, 2, fake.requests)
+}
@@ -0,0 +1,294 @@
+package heimdallmux
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallgrpc"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// defaultRequestTimeout bounds a single endpoint attempt, the underlying
+	// clients retry forever otherwise.
+	defaultRequestTimeout = 10 * time.Second
+
+	// healthDecay is the weight of the latest request in the moving averages
+	healthDecay = 0.2
+
+	// errorPenalty is the latency, in milliseconds, an always failing endpoint
+	// is charged with when endpoints are ranked
+	errorPenalty = 60_000
+)
+
+var (
+	errNoEndpoints = errors.New("no heimdall endpoints configured")
+
+	// ErrCrossCheckMismatch is returned when two endpoints disagree on a response
+	ErrCrossCheckMismatch = errors.New("heimdall endpoints returned different responses")
+)
+
+var _ bor.IHeimdallClient = (*HeimdallMuxClient)(nil)
+
+// Endpoint is a named heimdall client taking part in the failover.
+type Endpoint struct {
+	Name   string
+	Client bor.IHeimdallClient
+}
+
+// NewRESTEndpoint creates an endpoint backed by the heimdall REST API.
+func NewRESTEndpoint(url string) Endpoint {
+	return Endpoint{Name: metricName("rest", url), Client: heimdall.NewHeimdallClient(url)}
+}
+
+// NewGRPCEndpoint creates an endpoint backed by the heimdall gRPC server.
+func NewGRPCEndpoint(address string) Endpoint {
+	return Endpoint{Name: metricName("grpc", address), Client: heimdallgrpc.NewHeimdallGRPCClient(address)}
+}
+
+type endpoint struct {
+	name   string
+	client bor.IHeimdallClient
+	meters *endpointMeters
+
+	lock      sync.Mutex
+	latency   float64 // Moving average of the request latency in milliseconds
+	errorRate float64 // Moving average of failed requests, in [0, 1]
+}
+
+// score ranks the endpoint, the lower the healthier.
+func (e *endpoint) score() float64 {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.latency + e.errorRate*errorPenalty
+}
+
+func (e *endpoint) record(start time.Time, err error) {
+	failed := 0.0
+	if err != nil {
+		failed = 1
+	}
+
+	e.lock.Lock()
+	e.latency = (1-healthDecay)*e.latency + healthDecay*float64(time.Since(start).Milliseconds())
+	e.errorRate = (1-healthDecay)*e.errorRate + healthDecay*failed
+	score := e.latency + e.errorRate*errorPenalty
+	e.lock.Unlock()
+
+	e.meters.update(start, err == nil, score)
+}
+
+// HeimdallMuxClient spreads requests over several heimdall endpoints. Every
+// request goes to the healthiest endpoint first, falling back to the others in
+// order of health when it fails. Optionally span and milestone responses are
+// confirmed by a second endpoint.
+type HeimdallMuxClient struct {
+	endpoints      []*endpoint
+	requestTimeout time.Duration
+	crossCheck     bool
+}
+
+// NewHeimdallMuxClient creates a failover client over the given endpoints.
+func NewHeimdallMuxClient(endpoints []Endpoint, requestTimeout time.Duration, crossCheck bool) (*HeimdallMuxClient, error) {
+	if len(endpoints) == 0 {
+		return nil, errNoEndpoints
+	}
+
+	if requestTimeout == 0 {
+		requestTimeout = defaultRequestTimeout
+	}
+
+	h := &HeimdallMuxClient{
+		endpoints:      make([]*endpoint, 0, len(endpoints)),
+		requestTimeout: requestTimeout,
+		crossCheck:     crossCheck,
+	}
+
+	for _, e := range endpoints {
+		h.endpoints = append(h.endpoints, &endpoint{
+			name:   e.Name,
+			client: e.Client,
+			meters: newEndpointMeters(e.Name),
+		})
+	}
+
+	log.Info("Using multiple Heimdall endpoints", "count", len(endpoints), "crossCheck", crossCheck)
+
+	return h, nil
+}
+
+// ranked returns the endpoints ordered from the healthiest to the least healthy.
+func (h *HeimdallMuxClient) ranked() []*endpoint {
+	ranked := make([]*endpoint, len(h.endpoints))
+	copy(ranked, h.endpoints)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score() < ranked[j].score()
+	})
+
+	return ranked
+}
+
+// isDefinitive reports whether the error is a valid answer of heimdall rather
+// than a failure of the endpoint, so there is no point in asking another one.
+func isDefinitive(err error) bool {
+	return errors.Is(err, heimdall.ErrNotInRejectedList) ||
+		errors.Is(err, heimdall.ErrNotInMilestoneList) ||
+		errors.Is(err, heimdall.ErrServiceUnavailable)
+}
+
+// request calls fn on the endpoints in order of health until one succeeds.
+// It returns the index, in the ranking, of the endpoint which answered.
+func request[T any](ctx context.Context, h *HeimdallMuxClient, endpoints []*endpoint, fn func(context.Context, bor.IHeimdallClient) (T, error)) (T, int, error) {
+	var (
+		result T
+		err    error
+	)
+
+	for i, e := range endpoints {
+		reqCtx, cancel := context.WithTimeout(ctx, h.requestTimeout)
+		start := time.Now()
+
+		result, err = fn(reqCtx, e.client)
+
+		cancel()
+
+		if err == nil || isDefinitive(err) {
+			e.record(start, nil)
+			return result, i, err
+		}
+
+		e.record(start, err)
+
+		// The caller gave up, don't blame the other endpoints
+		if ctx.Err() != nil {
+			return result, i, ctx.Err()
+		}
+
+		log.Warn("Heimdall endpoint failed, trying next one", "endpoint", e.name, "err", err)
+	}
+
+	return result, len(endpoints), err
+}
+
+// crossChecked fetches a response and, when cross checking is enabled,
+// confirms it with the next healthy endpoint.
+func crossChecked[T any](ctx context.Context, h *HeimdallMuxClient, fn func(context.Context, bor.IHeimdallClient) (T, error)) (T, error) {
+	endpoints := h.ranked()
+
+	result, i, err := request(ctx, h, endpoints, fn)
+	if err != nil || !h.crossCheck || i+1 >= len(endpoints) {
+		return result, err
+	}
+
+	confirmation, j, err := request(ctx, h, endpoints[i+1:], fn)
+	if err != nil {
+		log.Warn("Unable to cross check Heimdall response", "endpoint", endpoints[i].name, "err", err)
+		return result, nil
+	}
+
+	expected, err := json.Marshal(result)
+	if err != nil {
+		return result, err
+	}
+
+	got, err := json.Marshal(confirmation)
+	if err != nil {
+		return result, err
+	}
+
+	if !bytes.Equal(expected, got) {
+		crossCheckMismatchMeter.Mark(1)
+
+		return result, fmt.Errorf("%w: %s and %s", ErrCrossCheckMismatch, endpoints[i].name, endpoints[i+1+j].name)
+	}
+
+	return result, nil
+}
+
+func (h *HeimdallMuxClient) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	events, _, err := request(ctx, h, h.ranked(), func(ctx context.Context, c bor.IHeimdallClient) ([]*clerk.EventRecordWithTime, error) {
+		return c.StateSyncEvents(ctx, fromID, to)
+	})
+
+	return events, err
+}
+
+func (h *HeimdallMuxClient) Span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	return crossChecked(ctx, h, func(ctx context.Context, c bor.IHeimdallClient) (*span.HeimdallSpan, error) {
+		return c.Span(ctx, spanID)
+	})
+}
+
+func (h *HeimdallMuxClient) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	cp, _, err := request(ctx, h, h.ranked(), func(ctx context.Context, c bor.IHeimdallClient) (*checkpoint.Checkpoint, error) {
+		return c.FetchCheckpoint(ctx, number)
+	})
+
+	return cp, err
+}
+
+func (h *HeimdallMuxClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	count, _, err := request(ctx, h, h.ranked(), func(ctx context.Context, c bor.IHeimdallClient) (int64, error) {
+		return c.FetchCheckpointCount(ctx)
+	})
+
+	return count, err
+}
+
+func (h *HeimdallMuxClient) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	return crossChecked(ctx, h, func(ctx context.Context, c bor.IHeimdallClient) (*milestone.Milestone, error) {
+		return c.FetchMilestone(ctx)
+	})
+}
+
+func (h *HeimdallMuxClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	count, _, err := request(ctx, h, h.ranked(), func(ctx context.Context, c bor.IHeimdallClient) (int64, error) {
+		return c.FetchMilestoneCount(ctx)
+	})
+
+	return count, err
+}
+
+func (h *HeimdallMuxClient) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	_, _, err := request(ctx, h, h.ranked(), func(ctx context.Context, c bor.IHeimdallClient) (struct{}, error) {
+		return struct{}{}, c.FetchNoAckMilestone(ctx, milestoneID)
+	})
+
+	return err
+}
+
+func (h *HeimdallMuxClient) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	milestoneID, _, err := request(ctx, h, h.ranked(), func(ctx context.Context, c bor.IHeimdallClient) (string, error) {
+		return c.FetchLastNoAckMilestone(ctx)
+	})
+
+	return milestoneID, err
+}
+
+func (h *HeimdallMuxClient) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	_, _, err := request(ctx, h, h.ranked(), func(ctx context.Context, c bor.IHeimdallClient) (struct{}, error) {
+		return struct{}{}, c.FetchMilestoneID(ctx, milestoneID)
+	})
+
+	return err
+}
+
+func (h *HeimdallMuxClient) Close() {
+	for _, e := range h.endpoints {
+		e.client.Close()
+	}
+}
@@ -0,0 +1,189 @@
+package heimdallmux
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errEndpointDown = errors.New("endpoint down")
+
+type fakeHeimdall struct {
+	down      bool
+	hang      bool
+	hash      common.Hash
+	requests  int
+	closed    bool
+	noAckErr  error
+	spanChain string
+}
+
+func (f *fakeHeimdall) fail(ctx context.Context) error {
+	f.requests++
+
+	if f.hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	if f.down {
+		return errEndpointDown
+	}
+
+	return nil
+}
+
+func (f *fakeHeimdall) StateSyncEvents(ctx context.Context, _ uint64, _ int64) ([]*clerk.EventRecordWithTime, error) {
+	return nil, f.fail(ctx)
+}
+
+func (f *fakeHeimdall) Span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+
+	return &span.HeimdallSpan{Span: span.Span{ID: spanID}, ChainID: f.spanChain}, nil
+}
+
+func (f *fakeHeimdall) FetchCheckpoint(ctx context.Context, _ int64) (*checkpoint.Checkpoint, error) {
+	return nil, f.fail(ctx)
+}
+
+func (f *fakeHeimdall) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	return 7, f.fail(ctx)
+}
+
+func (f *fakeHeimdall) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	if err := f.fail(ctx); err != nil {
+		return nil, err
+	}
+
+	return &milestone.Milestone{StartBlock: big.NewInt(0), EndBlock: big.NewInt(15), Hash: f.hash}, nil
+}
+
+func (f *fakeHeimdall) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	return 0, f.fail(ctx)
+}
+
+func (f *fakeHeimdall) FetchNoAckMilestone(ctx context.Context, _ string) error {
+	if err := f.fail(ctx); err != nil {
+		return err
+	}
+
+	return f.noAckErr
+}
+
+func (f *fakeHeimdall) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	return "", f.fail(ctx)
+}
+
+func (f *fakeHeimdall) FetchMilestoneID(ctx context.Context, _ string) error {
+	return f.fail(ctx)
+}
+
+func (f *fakeHeimdall) Close() {
+	f.closed = true
+}
+
+func newTestMux(t *testing.T, crossCheck bool, fakes ...*fakeHeimdall) *HeimdallMuxClient {
+	t.Helper()
+
+	endpoints := make([]Endpoint, 0, len(fakes))
+	for i, fake := range fakes {
+		endpoints = append(endpoints, Endpoint{Name: metricName("test", string(rune('a'+i))), Client: fake})
+	}
+
+	client, err := NewHeimdallMuxClient(endpoints, 50*time.Millisecond, crossCheck)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestMuxRequiresEndpoints(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewHeimdallMuxClient(nil, 0, false)
+	require.ErrorIs(t, err, errNoEndpoints)
+}
+
+func TestMuxFailover(t *testing.T) {
+	t.Parallel()
+
+	first := &fakeHeimdall{hang: true}
+	second := &fakeHeimdall{}
+	client := newTestMux(t, false, first, second)
+
+	count, err := client.FetchCheckpointCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(7), count)
+	require.Equal(t, 1, first.requests)
+	require.Equal(t, 1, second.requests)
+
+	// The failing endpoint is ranked last from now on
+	_, err = client.FetchCheckpointCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, first.requests)
+	require.Equal(t, 2, second.requests)
+
+	second.down = true
+
+	_, err = client.FetchCheckpointCount(context.Background())
+	require.Error(t, err)
+	require.Equal(t, 2, first.requests)
+	require.Equal(t, 3, second.requests)
+
+	client.Close()
+	require.True(t, first.closed)
+	require.True(t, second.closed)
+}
+
+func TestMuxDefinitiveErrors(t *testing.T) {
+	t.Parallel()
+
+	first := &fakeHeimdall{noAckErr: heimdall.ErrNotInRejectedList}
+	second := &fakeHeimdall{}
+	client := newTestMux(t, false, first, second)
+
+	err := client.FetchNoAckMilestone(context.Background(), "id")
+	require.ErrorIs(t, err, heimdall.ErrNotInRejectedList)
+	require.Equal(t, 0, second.requests)
+}
+
+func TestMuxCrossCheck(t *testing.T) {
+	t.Parallel()
+
+	first := &fakeHeimdall{hash: common.HexToHash("0x1"), spanChain: "15001"}
+	second := &fakeHeimdall{hash: common.HexToHash("0x1"), spanChain: "15001"}
+	client := newTestMux(t, true, first, second)
+
+	_, err := client.FetchMilestone(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, second.requests)
+
+	second.hash = common.HexToHash("0x2")
+
+	_, err = client.FetchMilestone(context.Background())
+	require.ErrorIs(t, err, ErrCrossCheckMismatch)
+
+	second.spanChain = "1"
+
+	_, err = client.Span(context.Background(), 1)
+	require.ErrorIs(t, err, ErrCrossCheckMismatch)
+
+	// Without a second endpoint available the response is accepted as is
+	second.down = true
+
+	_, err = client.Span(context.Background(), 1)
+	require.NoError(t, err)
+}
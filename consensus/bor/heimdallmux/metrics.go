@@ -0,0 +1,54 @@
+package heimdallmux
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var crossCheckMismatchMeter = metrics.NewRegisteredMeter("client/endpoints/crosscheck/mismatch", nil)
+
+// endpointMeters are the per-endpoint counterparts of the request meters of
+// the heimdall package.
+type endpointMeters struct {
+	request map[bool]metrics.Meter // map[isSuccessful]metrics.Meter
+	timer   metrics.Timer
+	score   metrics.GaugeFloat64
+}
+
+func newEndpointMeters(name string) *endpointMeters {
+	prefix := fmt.Sprintf("client/endpoints/%s", name)
+
+	return &endpointMeters{
+		request: map[bool]metrics.Meter{
+			true:  metrics.NewRegisteredMeter(prefix+"/valid", nil),
+			false: metrics.NewRegisteredMeter(prefix+"/invalid", nil),
+		},
+		timer: metrics.NewRegisteredTimer(prefix+"/duration", nil),
+		score: metrics.NewRegisteredGaugeFloat64(prefix+"/score", nil),
+	}
+}
+
+func (m *endpointMeters) update(start time.Time, isSuccessful bool, score float64) {
+	m.request[isSuccessful].Mark(1)
+	m.timer.Update(time.Since(start))
+	m.score.Update(score)
+}
+
+// metricName turns an endpoint address into a name usable in a metric path.
+func metricName(kind string, address string) string {
+	address = strings.TrimPrefix(address, "http://")
+	address = strings.TrimPrefix(address, "https://")
+
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+
+		return '_'
+	}, address)
+
+	return kind + "_" + name
+}
@@ -0,0 +1,115 @@
+package heimdallsim
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// FetchCheckpointCount returns the number of checkpoints whose end block is
+// part of the local chain.
+func (s *Simulator) FetchCheckpointCount(_ context.Context) (int64, error) {
+	log.Info("Fetching checkpoint count")
+
+	head, err := s.head()
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(s.checkpointCount(head)), nil
+}
+
+// FetchCheckpoint returns the checkpoint with the given number, or the latest
+// one when number is -1. Checkpoint n covers the blocks
+// [(n-1)*CheckpointInterval, n*CheckpointInterval-1].
+func (s *Simulator) FetchCheckpoint(_ context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	log.Info("Fetching checkpoint", "number", number)
+
+	head, err := s.head()
+	if err != nil {
+		return nil, err
+	}
+
+	count := s.checkpointCount(head)
+
+	if number == -1 {
+		number = int64(count)
+	}
+
+	if number <= 0 || uint64(number) > count {
+		return nil, fmt.Errorf("%w: checkpoint %d not found", heimdall.ErrNotSuccessfulResponse, number)
+	}
+
+	start := uint64(number-1) * s.config.CheckpointInterval
+	end := start + s.config.CheckpointInterval - 1
+
+	headers, err := s.headers(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	rootHash, err := bor.ComputeRootHash(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkpoint.Checkpoint{
+		Proposer:   s.proposer(uint64(number)),
+		StartBlock: new(big.Int).SetUint64(start),
+		EndBlock:   new(big.Int).SetUint64(end),
+		RootHash:   common.HexToHash(rootHash),
+		BorChainID: s.config.ChainID,
+		Timestamp:  headers[len(headers)-1].Time,
+	}, nil
+}
+
+func (s *Simulator) checkpointCount(head uint64) uint64 {
+	return (head + 1) / s.config.CheckpointInterval
+}
+
+// head returns the number of the current block of the attached chain.
+func (s *Simulator) head() (uint64, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.chain == nil {
+		return 0, fmt.Errorf("%w: no chain attached to heimdall simulator", heimdall.ErrServiceUnavailable)
+	}
+
+	header := s.chain.CurrentHeader()
+	if header == nil {
+		return 0, fmt.Errorf("%w: no current header", heimdall.ErrServiceUnavailable)
+	}
+
+	return header.Number.Uint64(), nil
+}
+
+func (s *Simulator) headers(start, end uint64) ([]*types.Header, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	headers := make([]*types.Header, 0, end-start+1)
+
+	for number := start; number <= end; number++ {
+		header := s.chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, fmt.Errorf("%w: missing header %d", heimdall.ErrServiceUnavailable, number)
+		}
+
+		headers = append(headers, header)
+	}
+
+	return headers, nil
+}
+
+// proposer rotates through the validator set, ordered by address.
+func (s *Simulator) proposer(number uint64) common.Address {
+	return s.validators[number%uint64(len(s.validators))].Address
+}
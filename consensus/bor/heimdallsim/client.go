@@ -0,0 +1,152 @@
+package heimdallsim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	defaultSpanLength             = 6400
+	defaultFirstSpanEnd           = 255
+	defaultCheckpointInterval     = 256
+	defaultMilestoneInterval      = 16
+	defaultMilestoneConfirmations = 16
+)
+
+var errNoValidators = errors.New("heimdall simulator requires at least one validator")
+
+var _ bor.IHeimdallClient = (*Simulator)(nil)
+
+// Config describes the deterministic heimdall the simulator impersonates.
+type Config struct {
+	// ChainID is the bor chain id reported in spans, checkpoints and milestones
+	ChainID string `json:"chainId"`
+
+	// Validators is the genesis validator set used for every span
+	Validators []*valset.Validator `json:"validators"`
+
+	// SpanLength is the number of blocks in every span after the first one
+	SpanLength uint64 `json:"spanLength,omitempty"`
+
+	// CheckpointInterval is the number of blocks covered by a checkpoint
+	CheckpointInterval uint64 `json:"checkpointInterval,omitempty"`
+
+	// MilestoneInterval is the number of blocks covered by a milestone
+	MilestoneInterval uint64 `json:"milestoneInterval,omitempty"`
+
+	// MilestoneConfirmations is the number of blocks a milestone end block
+	// has to be buried under before the milestone is proposed
+	MilestoneConfirmations uint64 `json:"milestoneConfirmations,omitempty"`
+
+	// Events is the script of state-sync events served by the simulator
+	Events []*clerk.EventRecordWithTime `json:"events,omitempty"`
+}
+
+// LoadConfig reads a simulator configuration from a json file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := new(Config)
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid heimdall simulator config %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// ChainReader is the subset of the blockchain used by the simulator to derive
+// checkpoints and milestones from the locally imported blocks.
+type ChainReader interface {
+	CurrentHeader() *types.Header
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// Simulator is an in-process heimdall which serves spans, state-sync events,
+// checkpoints and milestones derived deterministically from its Config.
+type Simulator struct {
+	config     Config
+	validators []*valset.Validator
+
+	lock         sync.RWMutex
+	chain        ChainReader
+	events       []*clerk.EventRecordWithTime
+	milestoneIDs map[string]struct{}
+}
+
+// NewSimulator creates a heimdall simulator from the given configuration.
+func NewSimulator(config *Config) (*Simulator, error) {
+	if len(config.Validators) == 0 {
+		return nil, errNoValidators
+	}
+
+	cfg := *config
+
+	if cfg.SpanLength == 0 {
+		cfg.SpanLength = defaultSpanLength
+	}
+
+	if cfg.CheckpointInterval == 0 {
+		cfg.CheckpointInterval = defaultCheckpointInterval
+	}
+
+	if cfg.MilestoneInterval == 0 {
+		cfg.MilestoneInterval = defaultMilestoneInterval
+	}
+
+	if cfg.MilestoneConfirmations == 0 {
+		cfg.MilestoneConfirmations = defaultMilestoneConfirmations
+	}
+
+	validators := make([]*valset.Validator, 0, len(cfg.Validators))
+	for i, val := range cfg.Validators {
+		val = val.Copy()
+		if val.ID == 0 {
+			val.ID = uint64(i + 1)
+		}
+
+		validators = append(validators, val)
+	}
+
+	sort.Sort(valset.ValidatorsByAddress(validators))
+
+	sim := &Simulator{
+		config:       cfg,
+		validators:   validators,
+		milestoneIDs: make(map[string]struct{}),
+	}
+
+	for _, event := range cfg.Events {
+		sim.AddStateSyncEvent(event)
+	}
+
+	log.Info("Started Heimdall simulator", "chainID", cfg.ChainID, "validators", len(validators), "events", len(sim.events))
+
+	return sim, nil
+}
+
+// SetChain attaches the local chain used to derive checkpoints and milestones.
+// Until a chain is set, checkpoint and milestone queries report the service as
+// unavailable.
+func (s *Simulator) SetChain(chain ChainReader) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.chain = chain
+}
+
+func (s *Simulator) Close() {
+	log.Debug("Shutdown detected, Closing Heimdall simulator")
+}
@@ -0,0 +1,156 @@
+package heimdallsim
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChain struct {
+	headers []*types.Header
+}
+
+func newFakeChain(length uint64) *fakeChain {
+	chain := &fakeChain{}
+
+	for i := uint64(0); i < length; i++ {
+		chain.headers = append(chain.headers, &types.Header{
+			Number: new(big.Int).SetUint64(i),
+			Time:   1000 + 2*i,
+		})
+	}
+
+	return chain
+}
+
+func (c *fakeChain) CurrentHeader() *types.Header {
+	return c.headers[len(c.headers)-1]
+}
+
+func (c *fakeChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c.headers)) {
+		return nil
+	}
+
+	return c.headers[number]
+}
+
+func newTestSimulator(t *testing.T) *Simulator {
+	t.Helper()
+
+	sim, err := NewSimulator(&Config{
+		ChainID: "15001",
+		Validators: []*valset.Validator{
+			valset.NewValidator(common.HexToAddress("0x2"), 10),
+			valset.NewValidator(common.HexToAddress("0x1"), 10),
+		},
+		SpanLength: 128,
+		Events: []*clerk.EventRecordWithTime{
+			{EventRecord: clerk.EventRecord{ID: 2}, Time: time.Unix(200, 0)},
+			{EventRecord: clerk.EventRecord{ID: 1}, Time: time.Unix(100, 0)},
+			{EventRecord: clerk.EventRecord{ID: 3, ChainID: "1"}, Time: time.Unix(300, 0)},
+		},
+	})
+	require.NoError(t, err)
+
+	return sim
+}
+
+func TestSimulatorRequiresValidators(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSimulator(&Config{ChainID: "15001"})
+	require.ErrorIs(t, err, errNoValidators)
+}
+
+func TestSimulatorSpan(t *testing.T) {
+	t.Parallel()
+
+	sim := newTestSimulator(t)
+
+	first, err := sim.Span(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), first.StartBlock)
+	require.Equal(t, uint64(255), first.EndBlock)
+
+	second, err := sim.Span(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(384), second.StartBlock)
+	require.Equal(t, uint64(511), second.EndBlock)
+	require.Equal(t, "15001", second.ChainID)
+	require.Len(t, second.ValidatorSet.Validators, 2)
+	require.Len(t, second.SelectedProducers, 2)
+	require.Equal(t, common.HexToAddress("0x1"), second.SelectedProducers[0].Address)
+}
+
+func TestSimulatorStateSyncEvents(t *testing.T) {
+	t.Parallel()
+
+	sim := newTestSimulator(t)
+
+	events, err := sim.StateSyncEvents(context.Background(), 1, 200)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(1), events[0].ID)
+	require.Equal(t, "15001", events[0].ChainID)
+
+	events, err = sim.StateSyncEvents(context.Background(), 2, 400)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, "1", events[1].ChainID)
+
+	sim.AddStateSyncEvent(&clerk.EventRecordWithTime{EventRecord: clerk.EventRecord{ID: 4}, Time: time.Unix(350, 0)})
+
+	events, err = sim.StateSyncEvents(context.Background(), 4, 400)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+}
+
+func TestSimulatorCheckpointsAndMilestones(t *testing.T) {
+	t.Parallel()
+
+	sim := newTestSimulator(t)
+
+	_, err := sim.FetchCheckpoint(context.Background(), -1)
+	require.True(t, errors.Is(err, heimdall.ErrServiceUnavailable))
+
+	chain := newFakeChain(600)
+	sim.SetChain(chain)
+
+	count, err := sim.FetchCheckpointCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	checkpoint, err := sim.FetchCheckpoint(context.Background(), -1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(256), checkpoint.StartBlock.Uint64())
+	require.Equal(t, uint64(511), checkpoint.EndBlock.Uint64())
+	require.Equal(t, chain.headers[511].Time, checkpoint.Timestamp)
+
+	_, err = sim.FetchCheckpoint(context.Background(), 3)
+	require.ErrorIs(t, err, heimdall.ErrNotSuccessfulResponse)
+
+	count, err = sim.FetchMilestoneCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(36), count)
+
+	milestone, err := sim.FetchMilestone(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(560), milestone.StartBlock.Uint64())
+	require.Equal(t, uint64(575), milestone.EndBlock.Uint64())
+	require.Equal(t, chain.headers[575].Hash(), milestone.Hash)
+
+	require.NoError(t, sim.FetchMilestoneID(context.Background(), MilestoneID(36, milestone.Hash)))
+	require.ErrorIs(t, sim.FetchMilestoneID(context.Background(), "unknown"), heimdall.ErrNotInMilestoneList)
+	require.ErrorIs(t, sim.FetchNoAckMilestone(context.Background(), "unknown"), heimdall.ErrNotInRejectedList)
+}
@@ -0,0 +1,110 @@
+package heimdallsim
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// FetchMilestoneCount returns the number of milestones proposed so far.
+func (s *Simulator) FetchMilestoneCount(_ context.Context) (int64, error) {
+	log.Info("Fetching milestone count")
+
+	head, err := s.head()
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(s.milestoneCount(head)), nil
+}
+
+// FetchMilestone returns the latest milestone. Milestone n covers the blocks
+// [(n-1)*MilestoneInterval, n*MilestoneInterval-1] and is proposed once its
+// end block has MilestoneConfirmations blocks on top of it.
+func (s *Simulator) FetchMilestone(_ context.Context) (*milestone.Milestone, error) {
+	log.Info("Fetching Latest Milestone")
+
+	head, err := s.head()
+	if err != nil {
+		return nil, err
+	}
+
+	number := s.milestoneCount(head)
+	if number == 0 {
+		return nil, fmt.Errorf("%w: no milestone proposed yet", heimdall.ErrServiceUnavailable)
+	}
+
+	start := (number - 1) * s.config.MilestoneInterval
+	end := start + s.config.MilestoneInterval - 1
+
+	headers, err := s.headers(end, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		hash = headers[0].Hash()
+		id   = MilestoneID(number, hash)
+	)
+
+	s.lock.Lock()
+	s.milestoneIDs[id] = struct{}{}
+	s.lock.Unlock()
+
+	return &milestone.Milestone{
+		Proposer:    s.proposer(number),
+		StartBlock:  new(big.Int).SetUint64(start),
+		EndBlock:    new(big.Int).SetUint64(end),
+		Hash:        hash,
+		BorChainID:  s.config.ChainID,
+		MilestoneID: id,
+		Timestamp:   headers[0].Time,
+	}, nil
+}
+
+// FetchLastNoAckMilestone returns an empty id, as the simulator never fails to
+// reach consensus on a milestone.
+func (s *Simulator) FetchLastNoAckMilestone(_ context.Context) (string, error) {
+	log.Info("Fetching Latest No Ack Milestone ID")
+
+	return "", nil
+}
+
+// FetchNoAckMilestone always reports the milestone as not rejected.
+func (s *Simulator) FetchNoAckMilestone(_ context.Context, milestoneID string) error {
+	log.Info("Fetching No Ack Milestone By MilestoneID", "MilestoneID", milestoneID)
+
+	return fmt.Errorf("%w: milestoneID %q", heimdall.ErrNotInRejectedList, milestoneID)
+}
+
+// FetchMilestoneID reports whether the milestone id was proposed by the simulator.
+func (s *Simulator) FetchMilestoneID(_ context.Context, milestoneID string) error {
+	log.Info("Fetching Milestone ID ", "MilestoneID", milestoneID)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if _, ok := s.milestoneIDs[milestoneID]; !ok {
+		return fmt.Errorf("%w: milestoneID %q", heimdall.ErrNotInMilestoneList, milestoneID)
+	}
+
+	return nil
+}
+
+// MilestoneID returns the id the simulator assigns to the n-th milestone.
+func MilestoneID(number uint64, hash common.Hash) string {
+	return fmt.Sprintf("sim-%d - %s", number, hash.Hex())
+}
+
+func (s *Simulator) milestoneCount(head uint64) uint64 {
+	if head < s.config.MilestoneConfirmations {
+		return 0
+	}
+
+	return (head - s.config.MilestoneConfirmations + 1) / s.config.MilestoneInterval
+}
@@ -0,0 +1,55 @@
+package heimdallsim
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Span returns the span with the given id. The first span covers the blocks
+// [0, 255] and every following span is SpanLength blocks long. All the spans
+// carry the genesis validator set, with every validator selected as producer.
+func (s *Simulator) Span(_ context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	log.Info("Fetching span", "spanID", spanID)
+
+	startBlock, endBlock := s.spanBounds(spanID)
+
+	validatorSet := valset.NewValidatorSet(s.validatorsCopy())
+
+	producers := make([]valset.Validator, 0, len(s.validators))
+	for _, val := range s.validators {
+		producers = append(producers, *val)
+	}
+
+	return &span.HeimdallSpan{
+		Span: span.Span{
+			ID:         spanID,
+			StartBlock: startBlock,
+			EndBlock:   endBlock,
+		},
+		ValidatorSet:      *validatorSet,
+		SelectedProducers: producers,
+		ChainID:           s.config.ChainID,
+	}, nil
+}
+
+func (s *Simulator) spanBounds(spanID uint64) (uint64, uint64) {
+	if spanID == 0 {
+		return 0, defaultFirstSpanEnd
+	}
+
+	startBlock := defaultFirstSpanEnd + 1 + (spanID-1)*s.config.SpanLength
+
+	return startBlock, startBlock + s.config.SpanLength - 1
+}
+
+func (s *Simulator) validatorsCopy() []*valset.Validator {
+	validators := make([]*valset.Validator, 0, len(s.validators))
+	for _, val := range s.validators {
+		validators = append(validators, val.Copy())
+	}
+
+	return validators
+}
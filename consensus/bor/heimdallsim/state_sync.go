@@ -0,0 +1,51 @@
+package heimdallsim
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// AddStateSyncEvent appends an event to the script of state-sync events. Events
+// without a chain id are assigned the chain id of the simulator.
+func (s *Simulator) AddStateSyncEvent(event *clerk.EventRecordWithTime) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	record := *event
+	if record.ChainID == "" {
+		record.ChainID = s.config.ChainID
+	}
+
+	s.events = append(s.events, &record)
+
+	sort.SliceStable(s.events, func(i, j int) bool {
+		return s.events[i].ID < s.events[j].ID
+	})
+}
+
+// StateSyncEvents returns the scripted events starting at fromID whose record
+// time is strictly before the to timestamp.
+func (s *Simulator) StateSyncEvents(_ context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	log.Info("Fetching state sync events", "fromID", fromID, "to", to)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	toTime := time.Unix(to, 0)
+	eventRecords := make([]*clerk.EventRecordWithTime, 0)
+
+	for _, event := range s.events {
+		if event.ID < fromID || !event.Time.Before(toTime) {
+			continue
+		}
+
+		record := *event
+		eventRecords = append(eventRecords, &record)
+	}
+
+	return eventRecords, nil
+}
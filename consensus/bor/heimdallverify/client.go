@@ -0,0 +1,223 @@
+package heimdallverify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallapp"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/maticnetwork/heimdall/app"
+	hmBor "github.com/maticnetwork/heimdall/bor"
+	borTypes "github.com/maticnetwork/heimdall/bor/types"
+	hmCheckpoint "github.com/maticnetwork/heimdall/checkpoint"
+	chTypes "github.com/maticnetwork/heimdall/checkpoint/types"
+	hmTypes "github.com/maticnetwork/heimdall/types"
+)
+
+var (
+	verifiedMeter   = metrics.NewRegisteredMeter("client/verify/valid", nil)
+	unverifiedMeter = metrics.NewRegisteredMeter("client/verify/invalid", nil)
+)
+
+// ErrUnverified is returned when a heimdall response can't be proven to be part
+// of the heimdall state agreed on by its validators.
+var ErrUnverified = errors.New("unable to verify heimdall response")
+
+var _ bor.IHeimdallClient = (*HeimdallVerifyClient)(nil)
+
+// HeimdallVerifyClient serves spans, checkpoints and milestones only after
+// verifying them with a tendermint light client: the values are read from the
+// heimdall state with a merkle proof, which has to resolve to the application
+// hash of a header signed by the heimdall validators. Everything else is
+// passed through to the wrapped client.
+type HeimdallVerifyClient struct {
+	client bor.IHeimdallClient
+	prover prover
+	cdc    *codec.Codec
+}
+
+// NewHeimdallVerifyClient creates a verifying heimdall client on top of client,
+// reading proofs from the tendermint RPC at tendermintURL. The light client
+// state is persisted in trustDir, if not empty. The trusted header is required
+// on the first start, until the light client state is persisted.
+func NewHeimdallVerifyClient(client bor.IHeimdallClient, tendermintURL string, trustDir string, trust TrustOptions) (*HeimdallVerifyClient, error) {
+	p, err := newTendermintProver(tendermintURL, trustDir, trust)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Verifying Heimdall responses", "tendermint", tendermintURL)
+
+	return newHeimdallVerifyClient(client, p), nil
+}
+
+func newHeimdallVerifyClient(client bor.IHeimdallClient, p prover) *HeimdallVerifyClient {
+	return &HeimdallVerifyClient{
+		client: client,
+		prover: p,
+		cdc:    app.MakeCodec(),
+	}
+}
+
+// get reads a proven value, giving up when ctx is done. The tendermint client
+// doesn't support cancellation, so an abandoned query finishes in the background.
+func (h *HeimdallVerifyClient) get(ctx context.Context, store string, key []byte, height int64) ([]byte, int64, error) {
+	type result struct {
+		value  []byte
+		height int64
+		err    error
+	}
+
+	resCh := make(chan result, 1)
+
+	go func() {
+		value, height, err := h.prover.get(store, key, height)
+		resCh <- result{value, height, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	case res := <-resCh:
+		if res.err != nil {
+			unverifiedMeter.Mark(1)
+			return nil, 0, fmt.Errorf("%w: %v", ErrUnverified, res.err)
+		}
+
+		verifiedMeter.Mark(1)
+
+		return res.value, res.height, nil
+	}
+}
+
+// getCount reads a proven counter, stored as a decimal string by heimdall.
+func (h *HeimdallVerifyClient) getCount(ctx context.Context, store string, key []byte) (uint64, int64, error) {
+	value, height, err := h.get(ctx, store, key, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	count, err := strconv.ParseUint(string(value), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: invalid count: %v", ErrUnverified, err)
+	}
+
+	return count, height, nil
+}
+
+func (h *HeimdallVerifyClient) decode(value []byte, out interface{}) error {
+	if err := h.cdc.UnmarshalBinaryBare(value, out); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnverified, err)
+	}
+
+	return nil
+}
+
+func (h *HeimdallVerifyClient) Span(ctx context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	value, _, err := h.get(ctx, borTypes.StoreKey, hmBor.GetSpanKey(spanID), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var hdSpan hmTypes.Span
+	if err := h.decode(value, &hdSpan); err != nil {
+		return nil, err
+	}
+
+	if hdSpan.ID != spanID {
+		return nil, fmt.Errorf("%w: got span %d, want %d", ErrUnverified, hdSpan.ID, spanID)
+	}
+
+	return heimdallapp.ToSpan(&hdSpan), nil
+}
+
+// FetchCheckpoint returns the proven checkpoint. The latest checkpoint (number
+// -1) is looked up with the checkpoint count proven at the same height.
+func (h *HeimdallVerifyClient) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	var height int64
+
+	if number == -1 {
+		count, countHeight, err := h.getCount(ctx, chTypes.StoreKey, hmCheckpoint.ACKCountKey)
+		if err != nil {
+			return nil, err
+		}
+
+		number, height = int64(count), countHeight
+	}
+
+	value, _, err := h.get(ctx, chTypes.StoreKey, hmCheckpoint.GetCheckpointKey(uint64(number)), height)
+	if err != nil {
+		return nil, err
+	}
+
+	var hdCheckpoint hmTypes.Checkpoint
+	if err := h.decode(value, &hdCheckpoint); err != nil {
+		return nil, err
+	}
+
+	return heimdallapp.ToBorCheckpoint(hdCheckpoint), nil
+}
+
+func (h *HeimdallVerifyClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	count, _, err := h.getCount(ctx, chTypes.StoreKey, hmCheckpoint.ACKCountKey)
+
+	return int64(count), err
+}
+
+// FetchMilestone returns the latest milestone, looked up with the milestone
+// count proven at the same height.
+func (h *HeimdallVerifyClient) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	count, height, err := h.getCount(ctx, chTypes.StoreKey, hmCheckpoint.CountKey)
+	if err != nil {
+		return nil, err
+	}
+
+	value, _, err := h.get(ctx, chTypes.StoreKey, hmCheckpoint.GetMilestoneKey(count), height)
+	if err != nil {
+		return nil, err
+	}
+
+	var hdMilestone hmTypes.Milestone
+	if err := h.decode(value, &hdMilestone); err != nil {
+		return nil, err
+	}
+
+	return heimdallapp.ToBorMilestone(&hdMilestone), nil
+}
+
+func (h *HeimdallVerifyClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	count, _, err := h.getCount(ctx, chTypes.StoreKey, hmCheckpoint.CountKey)
+
+	return int64(count), err
+}
+
+func (h *HeimdallVerifyClient) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	return h.client.StateSyncEvents(ctx, fromID, to)
+}
+
+func (h *HeimdallVerifyClient) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	return h.client.FetchNoAckMilestone(ctx, milestoneID)
+}
+
+func (h *HeimdallVerifyClient) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	return h.client.FetchLastNoAckMilestone(ctx)
+}
+
+func (h *HeimdallVerifyClient) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	return h.client.FetchMilestoneID(ctx, milestoneID)
+}
+
+func (h *HeimdallVerifyClient) Close() {
+	h.client.Close()
+}
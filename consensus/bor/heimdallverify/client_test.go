@@ -0,0 +1,188 @@
+package heimdallverify
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/maticnetwork/heimdall/app"
+	hmBor "github.com/maticnetwork/heimdall/bor"
+	borTypes "github.com/maticnetwork/heimdall/bor/types"
+	hmCheckpoint "github.com/maticnetwork/heimdall/checkpoint"
+	chTypes "github.com/maticnetwork/heimdall/checkpoint/types"
+	hmTypes "github.com/maticnetwork/heimdall/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errBadProof = errors.New("bad proof")
+
+// fakeProver serves values from a map, keyed by store and key, without any
+// proof. Values are only visible from the height they were set at.
+type fakeProver struct {
+	values  map[string][]byte
+	heights map[string]int64
+	latest  int64
+	invalid bool
+	block   chan struct{}
+}
+
+func newFakeProver() *fakeProver {
+	return &fakeProver{
+		values:  make(map[string][]byte),
+		heights: make(map[string]int64),
+		latest:  1,
+	}
+}
+
+func (p *fakeProver) set(t *testing.T, store string, key []byte, value interface{}) {
+	t.Helper()
+
+	var data []byte
+
+	if s, ok := value.(string); ok {
+		data = []byte(s)
+	} else {
+		var err error
+
+		data, err = app.MakeCodec().MarshalBinaryBare(value)
+		require.NoError(t, err)
+	}
+
+	p.latest++
+	p.values[store+string(key)] = data
+	p.heights[store+string(key)] = p.latest
+}
+
+func (p *fakeProver) get(store string, key []byte, height int64) ([]byte, int64, error) {
+	if p.block != nil {
+		<-p.block
+	}
+
+	if p.invalid {
+		return nil, 0, errBadProof
+	}
+
+	if height == 0 {
+		height = p.latest
+	}
+
+	value, ok := p.values[store+string(key)]
+	if !ok || p.heights[store+string(key)] > height {
+		return nil, height, errValueNotFound
+	}
+
+	return value, height, nil
+}
+
+func TestVerifySpan(t *testing.T) {
+	t.Parallel()
+
+	p := newFakeProver()
+	client := newHeimdallVerifyClient(nil, p)
+
+	validator := hmTypes.Validator{
+		ID:          hmTypes.NewValidatorID(1),
+		VotingPower: 100,
+		Signer:      hmTypes.HexToHeimdallAddress("0x0000000000000000000000000000000000000001"),
+	}
+
+	p.set(t, borTypes.StoreKey, hmBor.GetSpanKey(2), hmTypes.Span{
+		ID:                2,
+		StartBlock:        256,
+		EndBlock:          6655,
+		ValidatorSet:      hmTypes.ValidatorSet{Validators: []*hmTypes.Validator{&validator}, Proposer: &validator},
+		SelectedProducers: []hmTypes.Validator{validator},
+		ChainID:           "15001",
+	})
+
+	heimdallSpan, err := client.Span(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6655), heimdallSpan.EndBlock)
+	require.Equal(t, "15001", heimdallSpan.ChainID)
+	require.Len(t, heimdallSpan.SelectedProducers, 1)
+	require.Equal(t, common.HexToAddress("0x1"), heimdallSpan.SelectedProducers[0].Address)
+	require.Equal(t, int64(100), heimdallSpan.ValidatorSet.Validators[0].VotingPower)
+
+	_, err = client.Span(context.Background(), 3)
+	require.ErrorIs(t, err, ErrUnverified)
+
+	p.invalid = true
+
+	_, err = client.Span(context.Background(), 2)
+	require.ErrorIs(t, err, ErrUnverified)
+}
+
+func TestVerifyLatestCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	p := newFakeProver()
+	client := newHeimdallVerifyClient(nil, p)
+
+	for i := uint64(1); i <= 2; i++ {
+		p.set(t, chTypes.StoreKey, hmCheckpoint.GetCheckpointKey(i), hmTypes.Checkpoint{StartBlock: (i - 1) * 256, EndBlock: i*256 - 1})
+		p.set(t, chTypes.StoreKey, hmCheckpoint.ACKCountKey, strconv.FormatUint(i, 10))
+	}
+
+	count, err := client.FetchCheckpointCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	cp, err := client.FetchCheckpoint(context.Background(), -1)
+	require.NoError(t, err)
+	require.Equal(t, int64(511), cp.EndBlock.Int64())
+
+	cp, err = client.FetchCheckpoint(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(255), cp.EndBlock.Int64())
+
+	// A count pointing at a checkpoint unknown at the same height is rejected
+	p.set(t, chTypes.StoreKey, hmCheckpoint.ACKCountKey, "3")
+
+	_, err = client.FetchCheckpoint(context.Background(), -1)
+	require.ErrorIs(t, err, ErrUnverified)
+}
+
+func TestVerifyMilestone(t *testing.T) {
+	t.Parallel()
+
+	p := newFakeProver()
+	client := newHeimdallVerifyClient(nil, p)
+
+	p.set(t, chTypes.StoreKey, hmCheckpoint.GetMilestoneKey(1), hmTypes.Milestone{
+		StartBlock: 0,
+		EndBlock:   15,
+		Hash:       hmTypes.HexToHeimdallHash("0x01"),
+		BorChainID: "15001",
+	})
+	p.set(t, chTypes.StoreKey, hmCheckpoint.CountKey, "1")
+
+	m, err := client.FetchMilestone(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(15), m.EndBlock.Int64())
+	require.Equal(t, common.HexToHash("0x01"), m.Hash)
+
+	count, err := client.FetchMilestoneCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func TestVerifyContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	p := newFakeProver()
+	p.block = make(chan struct{})
+
+	defer close(p.block)
+
+	client := newHeimdallVerifyClient(nil, p)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.FetchMilestoneCount(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
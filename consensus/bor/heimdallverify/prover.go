@@ -0,0 +1,143 @@
+package heimdallverify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/lite"
+	liteclient "github.com/tendermint/tendermint/lite/client"
+	"github.com/tendermint/tendermint/lite/proxy"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// trustCacheSize is the number of trusted commits kept in memory in front of
+// the persistent trust store.
+const trustCacheSize = 10
+
+var (
+	errValueNotFound = errors.New("key not found in heimdall store")
+	errNoTrustRoot   = errors.New("trusted heimdall height and hash required to start verifying heimdall")
+	errTrustMismatch = errors.New("heimdall header doesn't match the trusted hash")
+)
+
+// TrustOptions is the heimdall header the light client initially trusts, as
+// configured by the operator. The validator set of the header is the root of
+// trust of every later verification, it mustn't be taken from the node being
+// verified.
+type TrustOptions struct {
+	Height int64  // Height of the trusted header
+	Hash   []byte // Hash of the trusted header
+}
+
+// prover reads values from the heimdall application state together with a
+// proof of their inclusion.
+type prover interface {
+	// get returns the value of key in the given module store at height, or at
+	// the latest height if height is 0, and the height the value was read at.
+	get(store string, key []byte, height int64) ([]byte, int64, error)
+}
+
+// tendermintProver queries a heimdall tendermint node. Every value is checked
+// against the IAVL proof returned along with it, and the application hash the
+// proof resolves to against a header signed by +2/3 of the heimdall validator
+// set, as tracked by a tendermint light client.
+type tendermintProver struct {
+	node     rpcclient.Client
+	verifier lite.Verifier
+	runtime  *merkle.ProofRuntime
+}
+
+// newTendermintProver connects to the tendermint RPC of a heimdall node. The
+// validator set trusted initially is the one of the header given by trust, it
+// is kept in trustDir across restarts, or in memory if trustDir is empty.
+func newTendermintProver(url string, trustDir string, trust TrustOptions) (*tendermintProver, error) {
+	node := rpcclient.NewHTTP(url, "/websocket")
+
+	status, err := node.Status()
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach heimdall tendermint node: %w", err)
+	}
+
+	chainID := status.NodeInfo.Network
+
+	var db dbm.DB = dbm.NewMemDB()
+	if trustDir != "" {
+		db = dbm.NewDB("trust-base", dbm.GoLevelDBBackend, trustDir)
+	}
+
+	trusted := lite.NewMultiProvider(
+		lite.NewDBProvider("trusted.mem", dbm.NewMemDB()).SetLimit(trustCacheSize),
+		lite.NewDBProvider("trusted.lvl", db),
+	)
+	source := liteclient.NewProvider(chainID, node)
+
+	verifier := lite.NewDynamicVerifier(chainID, trusted, source)
+	verifier.SetLogger(tmlog.NewNopLogger())
+
+	if err := initTrustRoot(trusted, source, chainID, trust); err != nil {
+		return nil, err
+	}
+
+	return &tendermintProver{
+		node:     node,
+		verifier: verifier,
+		runtime:  rootmulti.DefaultProofRuntime(),
+	}, nil
+}
+
+// initTrustRoot stores the commit of the trusted header, unless a commit was
+// trusted already on an earlier start. The commit is fetched from source, it is
+// only accepted if its header hashes to the configured one.
+func initTrustRoot(trusted lite.PersistentProvider, source lite.Provider, chainID string, trust TrustOptions) error {
+	if _, err := trusted.LatestFullCommit(chainID, 1, 1<<63-1); err == nil {
+		return nil
+	}
+
+	if trust.Height <= 0 || len(trust.Hash) == 0 {
+		return errNoTrustRoot
+	}
+
+	fc, err := source.LatestFullCommit(chainID, trust.Height, trust.Height)
+	if err != nil {
+		return fmt.Errorf("unable to fetch trusted heimdall commit at height %d: %w", trust.Height, err)
+	}
+
+	if fc.Height() != trust.Height {
+		return fmt.Errorf("%w: height %d, want %d", errTrustMismatch, fc.Height(), trust.Height)
+	}
+
+	if err := fc.ValidateFull(chainID); err != nil {
+		return fmt.Errorf("invalid trusted heimdall commit: %w", err)
+	}
+
+	if hash := fc.SignedHeader.Hash(); !bytes.Equal(hash, trust.Hash) {
+		return fmt.Errorf("%w: hash %X at height %d, want %X", errTrustMismatch, []byte(hash), trust.Height, trust.Hash)
+	}
+
+	if err := trusted.SaveFullCommit(fc); err != nil {
+		return fmt.Errorf("unable to store trusted heimdall commit: %w", err)
+	}
+
+	return nil
+}
+
+func (p *tendermintProver) get(store string, key []byte, height int64) ([]byte, int64, error) {
+	opts := rpcclient.ABCIQueryOptions{Height: height, Prove: true}
+
+	res, err := proxy.GetWithProofOptions(p.runtime, "/store/"+store+"/key", key, opts, p.node, p.verifier)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if res.Response.Value == nil {
+		return nil, res.Response.Height, errValueNotFound
+	}
+
+	return res.Response.Value, res.Response.Height, nil
+}
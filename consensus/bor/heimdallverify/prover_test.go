@@ -0,0 +1,62 @@
+package heimdallverify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/lite"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestInitTrustRoot(t *testing.T) {
+	t.Parallel()
+
+	const chainID = "heimdall-test"
+
+	keys := lite.GenSecpPrivKeys(4)
+	validators := keys.ToValidators(10, 0)
+
+	// The node serves the commits of heights 1 to 3, the ones of a fake
+	// validator set at height 4
+	source := lite.NewDBProvider("source", dbm.NewMemDB())
+
+	commits := make([]lite.FullCommit, 0, 3)
+	for height := int64(1); height <= 3; height++ {
+		fc := keys.GenFullCommit(chainID, height, nil, validators, validators, []byte("app"), []byte("params"), []byte("results"), 0, len(keys))
+		require.NoError(t, source.SaveFullCommit(fc))
+
+		commits = append(commits, fc)
+	}
+
+	fakeKeys := lite.GenSecpPrivKeys(4)
+	fakeValidators := fakeKeys.ToValidators(10, 0)
+	fake := fakeKeys.GenFullCommit(chainID, 4, nil, fakeValidators, fakeValidators, []byte("app"), []byte("params"), []byte("results"), 0, len(fakeKeys))
+	require.NoError(t, source.SaveFullCommit(fake))
+
+	newTrusted := func() lite.PersistentProvider { return lite.NewDBProvider("trusted", dbm.NewMemDB()) }
+
+	// Nothing is trusted from the node itself
+	require.ErrorIs(t, initTrustRoot(newTrusted(), source, chainID, TrustOptions{}), errNoTrustRoot)
+	require.ErrorIs(t, initTrustRoot(newTrusted(), source, chainID, TrustOptions{Height: 2}), errNoTrustRoot)
+
+	// The header served at the trusted height must hash to the trusted hash
+	err := initTrustRoot(newTrusted(), source, chainID, TrustOptions{Height: 4, Hash: commits[1].SignedHeader.Hash()})
+	require.ErrorIs(t, err, errTrustMismatch)
+
+	// No header at the trusted height
+	err = initTrustRoot(newTrusted(), source, chainID, TrustOptions{Height: 5, Hash: commits[1].SignedHeader.Hash()})
+	require.Error(t, err)
+	require.False(t, errors.Is(err, errNoTrustRoot))
+
+	trusted := newTrusted()
+	require.NoError(t, initTrustRoot(trusted, source, chainID, TrustOptions{Height: 2, Hash: commits[1].SignedHeader.Hash()}))
+
+	fc, err := trusted.LatestFullCommit(chainID, 1, 1<<63-1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), fc.Height())
+	require.Equal(t, validators.Hash(), fc.Validators.Hash())
+
+	// Once a commit is trusted, the options aren't needed anymore
+	require.NoError(t, initTrustRoot(trusted, source, chainID, TrustOptions{}))
+}
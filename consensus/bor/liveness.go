@@ -0,0 +1,323 @@
+package bor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// livenessChainHeadChanSize is the size of channel listening to ChainHeadEvent.
+	livenessChainHeadChanSize = 64
+
+	// maxLivenessBacklog is the maximum number of blocks the tracker catches up
+	// on after a gap, older blocks are skipped (e.g. while syncing).
+	maxLivenessBacklog = 1024
+)
+
+var (
+	livenessInTurnMeter  = metrics.NewRegisteredMeter("bor/liveness/inturn", nil)
+	livenessBackupMeter  = metrics.NewRegisteredMeter("bor/liveness/backup", nil)
+	livenessMissedMeter  = metrics.NewRegisteredMeter("bor/liveness/missed", nil)
+	livenessLatenessHist = metrics.NewRegisteredHistogram("bor/liveness/lateness", nil, metrics.NewExpDecaySample(1028, 0.015))
+
+	errLivenessTrackerDisabled = errors.New("validator liveness tracker is not running")
+)
+
+// LivenessChain is the chain the liveness tracker follows.
+type LivenessChain interface {
+	consensus.ChainHeaderReader
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// ValidatorPerformance is the block production record of a validator. Lateness
+// is measured in seconds past the earliest time the validator was allowed to
+// produce a block, given its succession number.
+type ValidatorPerformance struct {
+	Address         common.Address `json:"address"`
+	InTurnProduced  uint64         `json:"inTurnProduced"`
+	BackupProduced  uint64         `json:"backupProduced"`
+	MissedSlots     uint64         `json:"missedSlots"`
+	TotalLateness   uint64         `json:"totalLateness"`
+	AverageLateness float64        `json:"averageLateness"`
+	LastProduced    uint64         `json:"lastProduced"`
+	LastMissed      uint64         `json:"lastMissed"`
+}
+
+// LivenessTracker follows the chain head and records, for every validator, the
+// blocks produced in-turn or as a backup and the in-turn slots missed.
+type LivenessTracker struct {
+	bor   *Bor
+	chain LivenessChain
+	db    ethdb.Database
+
+	lock         sync.RWMutex
+	performances map[common.Address]*ValidatorPerformance
+
+	head *uint64 // Last block accounted for, nil if nothing was tracked yet, only used by the update loop
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newLivenessTracker(bor *Bor, chain LivenessChain, db ethdb.Database) *LivenessTracker {
+	t := &LivenessTracker{
+		bor:          bor,
+		chain:        chain,
+		db:           db,
+		performances: make(map[common.Address]*ValidatorPerformance),
+		head:         rawdb.ReadLivenessHead(db),
+		quit:         make(chan struct{}),
+	}
+
+	for address, data := range rawdb.ReadAllValidatorPerformances(db) {
+		performance := new(ValidatorPerformance)
+		if err := json.Unmarshal(data, performance); err != nil {
+			log.Warn("Discarding invalid validator performance", "address", address, "err", err)
+			continue
+		}
+
+		t.performances[address] = performance
+	}
+
+	return t
+}
+
+// StartLivenessTracker starts recording the block production of the validators
+// as new blocks are added to the chain.
+func (c *Bor) StartLivenessTracker(chain LivenessChain) {
+	t := newLivenessTracker(c, chain, c.db)
+
+	headCh := make(chan core.ChainHeadEvent, livenessChainHeadChanSize)
+	sub := chain.SubscribeChainHeadEvent(headCh)
+
+	t.wg.Add(1)
+
+	go func() {
+		defer t.wg.Done()
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-headCh:
+				t.update(ev.Block.Header())
+			case <-sub.Err():
+				return
+			case <-t.quit:
+				return
+			}
+		}
+	}()
+
+	c.livenessTracker.Store(t)
+
+	log.Info("Started validator liveness tracker")
+}
+
+func (t *LivenessTracker) stop() {
+	close(t.quit)
+	t.wg.Wait()
+}
+
+// update accounts for the blocks up to head which weren't seen yet.
+func (t *LivenessTracker) update(head *types.Header) {
+	number := head.Number.Uint64()
+	if number == 0 {
+		return
+	}
+
+	// Blocks replaced by a reorg were already accounted for
+	if t.head != nil && number <= *t.head {
+		return
+	}
+
+	from := number
+	if t.head != nil && number-*t.head <= maxLivenessBacklog {
+		from = *t.head + 1
+	}
+
+	batch := t.db.NewBatch()
+
+	for n := from; n <= number; n++ {
+		header := head
+		if n != number {
+			header = t.chain.GetHeaderByNumber(n)
+		}
+
+		if header == nil {
+			break
+		}
+
+		if err := t.account(batch, header); err != nil {
+			log.Debug("Unable to account for block production", "number", n, "err", err)
+		}
+	}
+
+	t.head = &number
+
+	if err := rawdb.WriteLivenessHead(batch, number); err != nil {
+		log.Warn("Failed to store liveness tracker head", "err", err)
+	}
+
+	if err := batch.Write(); err != nil {
+		log.Warn("Failed to store validator performances", "err", err)
+	}
+}
+
+// account records the production of a single block.
+func (t *LivenessTracker) account(batch ethdb.KeyValueWriter, header *types.Header) error {
+	number := header.Number.Uint64()
+
+	parent := t.chain.GetHeader(header.ParentHash, number-1)
+	if parent == nil {
+		return errUnknownBlock
+	}
+
+	snap, err := t.bor.snapshot(t.chain, number-1, header.ParentHash, nil)
+	if err != nil {
+		return err
+	}
+
+	signer, err := ecrecover(header, t.bor.signatures, t.bor.config)
+	if err != nil {
+		return err
+	}
+
+	succession, err := snap.GetSignerSuccessionNumber(signer)
+	if err != nil {
+		return err
+	}
+
+	// A block produced too soon would have been rejected, anything past the
+	// earliest allowed time is lateness
+	var lateness uint64
+	if !IsBlockOnTime(parent, header, number, succession, t.bor.config) {
+		lateness = header.Time - (parent.Time + CalcProducerDelay(number, succession, t.bor.config))
+	}
+
+	inTurn := Difficulty(snap.ValidatorSet, signer) == uint64(len(snap.ValidatorSet.Validators))
+	proposer := snap.ValidatorSet.GetProposer().Address
+
+	t.lock.Lock()
+	changed := t.record(number, signer, proposer, inTurn, lateness)
+	t.lock.Unlock()
+
+	return t.store(batch, changed)
+}
+
+// store persists the given performances. Only the update loop modifies them,
+// so they can be read without holding the lock.
+func (t *LivenessTracker) store(batch ethdb.KeyValueWriter, performances []*ValidatorPerformance) error {
+	for _, performance := range performances {
+		data, err := json.Marshal(performance)
+		if err != nil {
+			return err
+		}
+
+		if err := rawdb.WriteValidatorPerformance(batch, performance.Address, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// record updates the performances with a block produced by signer, returning
+// the performances that changed. The caller must hold the lock.
+func (t *LivenessTracker) record(number uint64, signer common.Address, proposer common.Address, inTurn bool, lateness uint64) []*ValidatorPerformance {
+	producer := t.performance(signer)
+	producer.LastProduced = number
+	producer.TotalLateness += lateness
+
+	livenessLatenessHist.Update(int64(lateness))
+
+	if inTurn {
+		producer.InTurnProduced++
+
+		livenessInTurnMeter.Mark(1)
+	} else {
+		producer.BackupProduced++
+
+		livenessBackupMeter.Mark(1)
+	}
+
+	producer.AverageLateness = float64(producer.TotalLateness) / float64(producer.InTurnProduced+producer.BackupProduced)
+	producer.updateMetrics()
+
+	if inTurn || proposer == signer {
+		return []*ValidatorPerformance{producer}
+	}
+
+	missed := t.performance(proposer)
+	missed.MissedSlots++
+	missed.LastMissed = number
+	missed.updateMetrics()
+
+	livenessMissedMeter.Mark(1)
+
+	return []*ValidatorPerformance{producer, missed}
+}
+
+func (t *LivenessTracker) performance(address common.Address) *ValidatorPerformance {
+	performance, ok := t.performances[address]
+	if !ok {
+		performance = &ValidatorPerformance{Address: address}
+		t.performances[address] = performance
+	}
+
+	return performance
+}
+
+func (p *ValidatorPerformance) updateMetrics() {
+	prefix := "bor/liveness/validators/" + p.Address.Hex()
+
+	metrics.GetOrRegisterGauge(prefix+"/inturn", nil).Update(int64(p.InTurnProduced))
+	metrics.GetOrRegisterGauge(prefix+"/backup", nil).Update(int64(p.BackupProduced))
+	metrics.GetOrRegisterGauge(prefix+"/missed", nil).Update(int64(p.MissedSlots))
+	metrics.GetOrRegisterGaugeFloat64(prefix+"/lateness", nil).Update(p.AverageLateness)
+}
+
+// Performances returns a copy of the performance of the given validators, or of
+// every tracked validator if none is given.
+func (t *LivenessTracker) Performances(addresses ...common.Address) []*ValidatorPerformance {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	performances := make([]*ValidatorPerformance, 0, len(t.performances))
+
+	if len(addresses) == 0 {
+		for _, performance := range t.performances {
+			cpy := *performance
+			performances = append(performances, &cpy)
+		}
+
+		sort.Slice(performances, func(i, j int) bool {
+			return bytes.Compare(performances[i].Address[:], performances[j].Address[:]) < 0
+		})
+
+		return performances
+	}
+
+	for _, address := range addresses {
+		cpy := ValidatorPerformance{Address: address}
+		if performance, ok := t.performances[address]; ok {
+			cpy = *performance
+		}
+
+		performances = append(performances, &cpy)
+	}
+
+	return performances
+}
@@ -0,0 +1,255 @@
+package bor
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestLivenessTrackerRecord(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		tracker = newLivenessTracker(nil, nil, db)
+
+		alice = common.HexToAddress("0x1")
+		bob   = common.HexToAddress("0x2")
+	)
+
+	// Alice produces two blocks in-turn, one of them late
+	require.NoError(t, tracker.store(db, tracker.record(1, alice, alice, true, 0)))
+	require.NoError(t, tracker.store(db, tracker.record(2, alice, alice, true, 4)))
+
+	// Bob steps in for Alice
+	require.NoError(t, tracker.store(db, tracker.record(3, bob, alice, false, 1)))
+
+	performances := tracker.Performances()
+	require.Len(t, performances, 2)
+	require.Equal(t, &ValidatorPerformance{
+		Address:         alice,
+		InTurnProduced:  2,
+		MissedSlots:     1,
+		TotalLateness:   4,
+		AverageLateness: 2,
+		LastProduced:    2,
+		LastMissed:      3,
+	}, performances[0])
+	require.Equal(t, uint64(1), performances[1].BackupProduced)
+	require.Equal(t, uint64(0), performances[1].MissedSlots)
+
+	// The record survives a restart
+	restarted := newLivenessTracker(nil, nil, db)
+	require.Equal(t, performances, restarted.Performances())
+
+	// Unknown validators have an empty record
+	carol := common.HexToAddress("0x3")
+	require.Equal(t, []*ValidatorPerformance{{Address: carol}}, restarted.Performances(carol))
+}
+
+// livenessTestChain is a chain of headers the liveness tracker can follow.
+type livenessTestChain struct {
+	config  *params.ChainConfig
+	headers []*types.Header
+	feed    event.Feed
+}
+
+func (c *livenessTestChain) Config() *params.ChainConfig { return c.config }
+
+func (c *livenessTestChain) CurrentHeader() *types.Header { return c.headers[len(c.headers)-1] }
+
+func (c *livenessTestChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.GetHeaderByNumber(number); header != nil && header.Hash() == hash {
+		return header
+	}
+
+	return nil
+}
+
+func (c *livenessTestChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c.headers)) {
+		return nil
+	}
+
+	return c.headers[number]
+}
+
+func (c *livenessTestChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	for _, header := range c.headers {
+		if header.Hash() == hash {
+			return header
+		}
+	}
+
+	return nil
+}
+
+func (c *livenessTestChain) GetTd(hash common.Hash, number uint64) *big.Int { return nil }
+
+func (c *livenessTestChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+// newLivenessTestChain creates a bor engine with two validators of the same
+// power and a genesis header, it returns the keys of the proposer of the first
+// sprint and of its backup.
+func newLivenessTestChain(t *testing.T) (*Bor, *livenessTestChain, *ecdsa.PrivateKey, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	keys := make(map[common.Address]*ecdsa.PrivateKey)
+	validators := make([]*valset.Validator, 0, 2)
+
+	for i := 0; i < 2; i++ {
+		key, _ := crypto.GenerateKey()
+		address := crypto.PubkeyToAddress(key.PublicKey)
+
+		keys[address] = key
+		validators = append(validators, valset.NewValidator(address, 10))
+	}
+
+	config := &params.ChainConfig{
+		Bor: &params.BorConfig{
+			Period:           map[string]uint64{"0": 2},
+			ProducerDelay:    map[string]uint64{"0": 4},
+			Sprint:           map[string]uint64{"0": 64},
+			BackupMultiplier: map[string]uint64{"0": 2},
+		},
+	}
+
+	chain := &livenessTestChain{
+		config:  config,
+		headers: []*types.Header{{Number: big.NewInt(0), Time: 100}},
+	}
+
+	spanner := NewMockSpanner(gomock.NewController(t))
+	spanner.EXPECT().GetCurrentValidatorsByHash(gomock.Any(), chain.headers[0].Hash(), uint64(1)).Return(validators, nil).AnyTimes()
+
+	b := New(config, rawdb.NewMemoryDatabase(), nil, spanner, nil, nil, false)
+
+	snap, err := b.snapshot(chain, 0, chain.headers[0].Hash(), nil)
+	require.NoError(t, err)
+
+	proposer := snap.ValidatorSet.GetProposer().Address
+	for address, key := range keys {
+		if address != proposer {
+			return b, chain, keys[proposer], key
+		}
+	}
+
+	t.Fatal("missing backup validator")
+
+	return nil, nil, nil, nil
+}
+
+// extend appends a header signed by the given key, produced delay seconds after
+// its parent.
+func (c *livenessTestChain) extend(t *testing.T, key *ecdsa.PrivateKey, delay uint64) *types.Header {
+	t.Helper()
+
+	parent := c.CurrentHeader()
+	header := signedHeader(t, key, c.config.Bor, int64(len(c.headers)), parent.Hash(), parent.Time+delay)
+
+	c.headers = append(c.headers, header)
+
+	return header
+}
+
+func TestLivenessTrackerAccount(t *testing.T) {
+	t.Parallel()
+
+	b, chain, proposer, backup := newLivenessTestChain(t)
+
+	var (
+		tracker         = newLivenessTracker(b, chain, b.db)
+		proposerAddress = crypto.PubkeyToAddress(proposer.PublicKey)
+		backupAddress   = crypto.PubkeyToAddress(backup.PublicKey)
+	)
+
+	// The proposer produces its block on time
+	require.NoError(t, tracker.account(b.db, chain.extend(t, proposer, 2)))
+
+	// The backup steps in a second after its own slot
+	require.NoError(t, tracker.account(b.db, chain.extend(t, backup, 5)))
+
+	require.Equal(t, []*ValidatorPerformance{
+		{
+			Address:        proposerAddress,
+			InTurnProduced: 1,
+			MissedSlots:    1,
+			LastProduced:   1,
+			LastMissed:     2,
+		},
+		{
+			Address:         backupAddress,
+			BackupProduced:  1,
+			TotalLateness:   1,
+			AverageLateness: 1,
+			LastProduced:    2,
+		},
+	}, tracker.Performances(proposerAddress, backupAddress))
+
+	// A block whose parent is unknown isn't accounted for
+	orphan := signedHeader(t, proposer, chain.config.Bor, 3, common.HexToHash("0x1"), 110)
+	require.ErrorIs(t, tracker.account(b.db, orphan), errUnknownBlock)
+}
+
+func TestLivenessTrackerUpdate(t *testing.T) {
+	t.Parallel()
+
+	b, chain, proposer, backup := newLivenessTestChain(t)
+
+	var (
+		tracker         = newLivenessTracker(b, chain, b.db)
+		proposerAddress = crypto.PubkeyToAddress(proposer.PublicKey)
+		backupAddress   = crypto.PubkeyToAddress(backup.PublicKey)
+	)
+
+	// The first head is accounted for on its own
+	first := chain.extend(t, proposer, 2)
+	tracker.update(first)
+
+	// The blocks between the heads are caught up on: the proposer is a second
+	// late, then the backup steps in for it
+	chain.extend(t, proposer, 3)
+	chain.extend(t, backup, 4)
+	head := chain.extend(t, proposer, 2)
+
+	tracker.update(head)
+
+	// A head replaced by a reorg was already accounted for
+	tracker.update(chain.headers[2])
+
+	performances := tracker.Performances(proposerAddress, backupAddress)
+	require.Equal(t, &ValidatorPerformance{
+		Address:         proposerAddress,
+		InTurnProduced:  3,
+		MissedSlots:     1,
+		TotalLateness:   1,
+		AverageLateness: 1.0 / 3,
+		LastProduced:    4,
+		LastMissed:      3,
+	}, performances[0])
+	require.Equal(t, &ValidatorPerformance{
+		Address:        backupAddress,
+		BackupProduced: 1,
+		LastProduced:   3,
+	}, performances[1])
+
+	// The head and the performances are stored
+	require.Equal(t, uint64(4), *rawdb.ReadLivenessHead(b.db))
+
+	restarted := newLivenessTracker(b, chain, b.db)
+	require.Equal(t, performances, restarted.Performances(proposerAddress, backupAddress))
+}
@@ -0,0 +1,175 @@
+package bor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// SlashingProtectionFormatVersion is the version of the slashing protection
+// interchange format.
+const SlashingProtectionFormatVersion = "1"
+
+var (
+	slashingProtectionRefusedMeter = metrics.NewRegisteredMeter("bor/slashingprotection/refused", nil)
+
+	errSlashingProtectionVersion = errors.New("unsupported slashing protection interchange format version")
+)
+
+// SlashingProtectionInterchange is the JSON format the sealed headers are
+// exported to and imported from, to move a block producer between hosts.
+type SlashingProtectionInterchange struct {
+	Metadata SlashingProtectionMetadata `json:"metadata"`
+	Data     []SlashingProtectionSigner `json:"data"`
+}
+
+// SlashingProtectionMetadata identifies the format and the chain of the
+// exported records.
+type SlashingProtectionMetadata struct {
+	InterchangeFormatVersion string      `json:"interchange_format_version"`
+	GenesisHash              common.Hash `json:"genesis_hash"`
+}
+
+// SlashingProtectionSigner lists the headers sealed by a signer.
+type SlashingProtectionSigner struct {
+	Signer        common.Address      `json:"signer"`
+	SealedHeaders []SealedHeaderEntry `json:"sealed_headers"`
+}
+
+// SealedHeaderEntry is a header sealed at a block number.
+type SealedHeaderEntry struct {
+	Number   uint64      `json:"number,string"`
+	SealHash common.Hash `json:"seal_hash"`
+}
+
+// SlashingProtection records the seal hash of every header sealed by the node
+// and refuses to seal a different header at a block number already sealed, so
+// that a restarted or migrated block producer never signs two blocks at the
+// same height.
+type SlashingProtection struct {
+	config *params.BorConfig
+	db     ethdb.Database
+	lock   sync.Mutex
+}
+
+// NewSlashingProtection creates the slashing protection backed by the given
+// database.
+func NewSlashingProtection(config *params.BorConfig, db ethdb.Database) *SlashingProtection {
+	return &SlashingProtection{config: config, db: db}
+}
+
+// check records the header as sealed by signer, unless a different header was
+// sealed at the same block number before. The record is written before the
+// header is signed, so a crash can't lose it.
+func (p *SlashingProtection) check(signer common.Address, header *types.Header) error {
+	number := header.Number.Uint64()
+	sealHash := SealHash(header, p.config)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	sealed := rawdb.ReadSealedHeader(p.db, number, signer)
+	if sealed == sealHash {
+		return nil
+	}
+
+	if sealed != (common.Hash{}) {
+		slashingProtectionRefusedMeter.Mark(1)
+
+		return &SlashingProtectionError{Number: number, Signer: signer, Sealed: sealed, Requested: sealHash}
+	}
+
+	return rawdb.WriteSealedHeader(p.db, number, signer, sealHash)
+}
+
+// Export returns every sealed header recorded, in the interchange format.
+func (p *SlashingProtection) Export() *SlashingProtectionInterchange {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	signers := make(map[common.Address]*SlashingProtectionSigner)
+
+	rawdb.IterateSealedHeaders(p.db, func(number uint64, signer common.Address, sealHash common.Hash) bool {
+		entry, ok := signers[signer]
+		if !ok {
+			entry = &SlashingProtectionSigner{Signer: signer, SealedHeaders: make([]SealedHeaderEntry, 0)}
+			signers[signer] = entry
+		}
+
+		entry.SealedHeaders = append(entry.SealedHeaders, SealedHeaderEntry{Number: number, SealHash: sealHash})
+
+		return true
+	})
+
+	interchange := &SlashingProtectionInterchange{
+		Metadata: SlashingProtectionMetadata{
+			InterchangeFormatVersion: SlashingProtectionFormatVersion,
+			GenesisHash:              rawdb.ReadCanonicalHash(p.db, 0),
+		},
+		Data: make([]SlashingProtectionSigner, 0, len(signers)),
+	}
+
+	for _, entry := range signers {
+		interchange.Data = append(interchange.Data, *entry)
+	}
+
+	sort.Slice(interchange.Data, func(i, j int) bool {
+		return bytes.Compare(interchange.Data[i].Signer[:], interchange.Data[j].Signer[:]) < 0
+	})
+
+	return interchange
+}
+
+// Import adds the sealed headers of the interchange to the records, returning
+// the number of headers imported. Headers conflicting with a recorded one are
+// skipped, the recorded header keeps being the only one allowed at its height.
+func (p *SlashingProtection) Import(interchange *SlashingProtectionInterchange) (int, error) {
+	if interchange.Metadata.InterchangeFormatVersion != SlashingProtectionFormatVersion {
+		return 0, fmt.Errorf("%w: %q", errSlashingProtectionVersion, interchange.Metadata.InterchangeFormatVersion)
+	}
+
+	if genesis := rawdb.ReadCanonicalHash(p.db, 0); genesis != (common.Hash{}) && genesis != interchange.Metadata.GenesisHash {
+		return 0, fmt.Errorf("slashing protection genesis mismatch: have %x, want %x", interchange.Metadata.GenesisHash, genesis)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var (
+		batch    = p.db.NewBatch()
+		imported int
+	)
+
+	for _, entry := range interchange.Data {
+		for _, header := range entry.SealedHeaders {
+			switch sealed := rawdb.ReadSealedHeader(p.db, header.Number, entry.Signer); sealed {
+			case header.SealHash:
+				continue
+			case common.Hash{}:
+				if err := rawdb.WriteSealedHeader(batch, header.Number, entry.Signer, header.SealHash); err != nil {
+					return 0, err
+				}
+
+				imported++
+			default:
+				log.Warn("Skipping conflicting sealed header", "signer", entry.Signer, "number", header.Number, "recorded", sealed, "imported", header.SealHash)
+			}
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+
+	return imported, nil
+}
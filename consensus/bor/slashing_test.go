@@ -0,0 +1,150 @@
+package bor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSlashingProtection(t *testing.T) {
+	t.Parallel()
+
+	var (
+		config = &params.BorConfig{}
+		db     = rawdb.NewMemoryDatabase()
+		signer = common.HexToAddress("0x1")
+		other  = common.HexToAddress("0x2")
+	)
+
+	rawdb.WriteCanonicalHash(db, common.Hash{0x1}, 0)
+
+	header := func(number int64, time uint64) *types.Header {
+		return &types.Header{
+			Number:     big.NewInt(number),
+			Difficulty: big.NewInt(1),
+			Time:       time,
+			Extra:      make([]byte, types.ExtraVanityLength+types.ExtraSealLength),
+		}
+	}
+
+	protection := NewSlashingProtection(config, db)
+
+	require.NoError(t, protection.check(signer, header(10, 100)))
+
+	// Sealing the same header again is fine, a different one isn't
+	require.NoError(t, protection.check(signer, header(10, 100)))
+
+	var slashingErr *SlashingProtectionError
+	require.True(t, errors.As(protection.check(signer, header(10, 101)), &slashingErr))
+	require.Equal(t, uint64(10), slashingErr.Number)
+	require.Equal(t, SealHash(header(10, 100), config), slashingErr.Sealed)
+
+	// Other signers and heights are not affected
+	require.NoError(t, protection.check(other, header(10, 101)))
+	require.NoError(t, protection.check(signer, header(11, 102)))
+
+	// The records survive a restart
+	require.Error(t, NewSlashingProtection(config, db).check(signer, header(11, 103)))
+
+	// Export and import into another database
+	data, err := json.Marshal(protection.Export())
+	require.NoError(t, err)
+
+	interchange := new(SlashingProtectionInterchange)
+	require.NoError(t, json.Unmarshal(data, interchange))
+	require.Equal(t, common.Hash{0x1}, interchange.Metadata.GenesisHash)
+	require.Len(t, interchange.Data, 2)
+	require.Equal(t, signer, interchange.Data[0].Signer)
+	require.Len(t, interchange.Data[0].SealedHeaders, 2)
+
+	imported := rawdb.NewMemoryDatabase()
+	rawdb.WriteCanonicalHash(imported, common.Hash{0x1}, 0)
+
+	importedProtection := NewSlashingProtection(config, imported)
+
+	// Conflicting records are kept as they are
+	require.NoError(t, importedProtection.check(signer, header(11, 104)))
+
+	count, err := importedProtection.Import(interchange)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	require.NoError(t, importedProtection.check(other, header(10, 101)))
+	require.Error(t, importedProtection.check(signer, header(10, 101)))
+	require.NoError(t, importedProtection.check(signer, header(11, 104)))
+
+	// Records of another chain are refused
+	interchange.Metadata.GenesisHash = common.Hash{0x2}
+	_, err = importedProtection.Import(interchange)
+	require.Error(t, err)
+
+	interchange.Metadata.GenesisHash = common.Hash{0x1}
+	interchange.Metadata.InterchangeFormatVersion = "2"
+	_, err = importedProtection.Import(interchange)
+	require.ErrorIs(t, err, errSlashingProtectionVersion)
+}
+
+// Tests that a seal stopped before its delay doesn't record the height, so that
+// another header can be sealed at that height, and that a published header
+// does.
+func TestSealSlashingProtection(t *testing.T) {
+	t.Parallel()
+
+	b, chain, key, _ := newLivenessTestChain(t)
+
+	b.Authorize(crypto.PubkeyToAddress(key.PublicKey), func(_ accounts.Account, _ string, data []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(data), key)
+	})
+
+	block := func(delay time.Duration, extra byte) *types.Block {
+		header := &types.Header{
+			Number:     big.NewInt(1),
+			ParentHash: chain.CurrentHeader().Hash(),
+			Difficulty: big.NewInt(1),
+			Time:       uint64(time.Now().Add(delay).Unix()),
+			Extra:      make([]byte, types.ExtraVanityLength+types.ExtraSealLength),
+		}
+		header.Extra[0] = extra
+
+		return types.NewBlockWithHeader(header)
+	}
+
+	results := make(chan *types.Block, 1)
+
+	// The first seal is stopped before its delay, e.g. by a new head
+	stop := make(chan struct{})
+	require.NoError(t, b.Seal(context.Background(), chain, block(time.Hour, 1), results, stop))
+	close(stop)
+
+	// Another header can be sealed at the same height
+	sealed := block(0, 2)
+	require.NoError(t, b.Seal(context.Background(), chain, sealed, results, make(chan struct{})))
+
+	select {
+	case result := <-results:
+		require.Equal(t, SealHash(sealed.Header(), b.config), SealHash(result.Header(), b.config))
+	case <-time.After(5 * time.Second):
+		t.Fatal("block not sealed")
+	}
+
+	// Once published, no other header is sealed at that height
+	require.NoError(t, b.Seal(context.Background(), chain, block(0, 3), results, make(chan struct{})))
+
+	select {
+	case <-results:
+		t.Fatal("sealed two headers at the same height")
+	case <-time.After(500 * time.Millisecond):
+	}
+}
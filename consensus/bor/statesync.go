@@ -0,0 +1,197 @@
+package bor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SimulatedStateSync is the outcome of committing an event record in a dry run
+// of the state syncs of a block. Rejected records aren't committed.
+type SimulatedStateSync struct {
+	Record    *clerk.EventRecordWithTime `json:"record"`
+	Committed bool                       `json:"committed"`
+	Rejection string                     `json:"rejection,omitempty"`
+	GasUsed   uint64                     `json:"gasUsed"`
+	Logs      []*types.Log               `json:"logs"`
+}
+
+// StateSyncSimulation is the outcome of a dry run of the state syncs of a
+// block. The events emitted on L1 after the last committed state sync, and
+// before To, are fetched from Heimdall.
+type StateSyncSimulation struct {
+	Number      uint64                `json:"number"`
+	LastStateID uint64                `json:"lastStateId"`
+	To          time.Time             `json:"to"`
+	FetchError  string                `json:"fetchError,omitempty"`
+	GasUsed     uint64                `json:"gasUsed"`
+	Records     []*SimulatedStateSync `json:"records"`
+}
+
+// SimulateStateSync replays the commit of the state syncs of a block starting
+// a sprint on a copy of the given state, usually the one of its parent. As in
+// Finalize, the next span is committed first if the block needs it. The event
+// records are validated and committed like CommitStates does, nothing is written
+// but the outcome of every record is returned. A failed commit fails the whole
+// simulation, as it fails the block.
+func (c *Bor) SimulateStateSync(ctx context.Context, state *state.StateDB, header *types.Header, chain consensus.ChainHeaderReader) (*StateSyncSimulation, error) {
+	number := header.Number.Uint64()
+
+	if !IsSprintStart(number, c.config.CalculateSprint(number)) {
+		return nil, fmt.Errorf("block %d doesn't start a sprint, no state sync is committed in it", number)
+	}
+
+	if c.HeimdallClient == nil {
+		return nil, errNoHeimdallClient
+	}
+
+	var (
+		statedb = state.Copy()
+		cx      = statefull.ChainContext{Chain: chain, Bor: c}
+	)
+
+	if err := c.checkAndCommitSpan(ctx, statedb, header, cx); err != nil {
+		return nil, fmt.Errorf("failed to commit span: %w", err)
+	}
+
+	lastStateID, to, err := c.stateSyncWindow(statedb, header, cx)
+	if err != nil {
+		return nil, err
+	}
+
+	simulation := &StateSyncSimulation{
+		Number:      number,
+		LastStateID: lastStateID,
+		To:          to,
+		Records:     make([]*SimulatedStateSync, 0),
+	}
+
+	eventRecords, err := c.HeimdallClient.StateSyncEvents(ctx, lastStateID+1, to.Unix())
+	if err != nil {
+		simulation.FetchError = err.Error()
+	}
+
+	limit := len(eventRecords)
+
+	if c.config.OverrideStateSyncRecords != nil {
+		if val, ok := c.config.OverrideStateSyncRecords[strconv.FormatUint(number, 10)]; ok && val < limit {
+			limit = val
+		}
+	}
+
+	var (
+		chainID  = c.chainConfig.ChainID.String()
+		budget   = c.newStateSyncBudget(header.Number)
+		rejected bool
+	)
+
+	for i, eventRecord := range eventRecords {
+		result := &SimulatedStateSync{Record: eventRecord, Logs: make([]*types.Log, 0)}
+		simulation.Records = append(simulation.Records, result)
+
+		switch {
+		case i >= limit:
+			result.Rejection = "dropped by the state sync records override of the block"
+			continue
+		case rejected:
+			result.Rejection = "not processed after the rejection of an earlier record"
+			continue
+		case eventRecord.ID <= lastStateID:
+			result.Rejection = "already committed"
+			continue
+		case budget.exhausted():
+			result.Rejection = "carried over to the next sprint by the state sync budget"
+			continue
+		}
+
+		if err := validateEventRecord(eventRecord, number, to, lastStateID, chainID); err != nil {
+			result.Rejection = eventRecordRejection(eventRecord, to, lastStateID, chainID)
+			rejected = true
+
+			continue
+		}
+
+		logIndex := uint(len(statedb.Logs()))
+
+		result.GasUsed, err = c.GenesisContractsClient.CommitState(eventRecord, statedb, header, cx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to commit state sync %d: %w", eventRecord.ID, err)
+		}
+
+		for _, l := range statedb.Logs() {
+			if l.Index >= logIndex {
+				result.Logs = append(result.Logs, l)
+			}
+		}
+
+		sort.Slice(result.Logs, func(i, j int) bool {
+			return result.Logs[i].Index < result.Logs[j].Index
+		})
+
+		result.Committed = true
+		simulation.GasUsed += result.GasUsed
+		budget.spend(result.GasUsed)
+
+		lastStateID++
+	}
+
+	return simulation, nil
+}
+
+// stateSyncBudget limits the state syncs committed in a sprint once the state
+// sync budget fork is active. The records left out are carried over to the next
+// sprints, which fetch the records following the last committed one.
+type stateSyncBudget struct {
+	gas     uint64 // Gas the commits may use, 0 if unlimited
+	records uint64 // Number of records which may be committed, 0 if unlimited
+
+	usedGas     uint64
+	usedRecords uint64
+}
+
+func (c *Bor) newStateSyncBudget(number *big.Int) *stateSyncBudget {
+	if !c.config.IsStateSyncBudget(number) {
+		return &stateSyncBudget{}
+	}
+
+	return &stateSyncBudget{
+		gas:     c.config.CalculateStateSyncGasBudget(number.Uint64()),
+		records: c.config.CalculateStateSyncRecordBudget(number.Uint64()),
+	}
+}
+
+// exhausted reports whether no other record may be committed in the sprint.
+// The gas used by a commit is only known once it is executed, the commit
+// reaching the gas budget is kept and the following ones are carried over.
+func (b *stateSyncBudget) exhausted() bool {
+	return (b.records > 0 && b.usedRecords >= b.records) || (b.gas > 0 && b.usedGas >= b.gas)
+}
+
+// spend accounts for a committed record.
+func (b *stateSyncBudget) spend(gas uint64) {
+	b.usedGas += gas
+	b.usedRecords++
+}
+
+// eventRecordRejection explains why validateEventRecord rejects an event record.
+func eventRecordRejection(eventRecord *clerk.EventRecordWithTime, to time.Time, lastStateID uint64, chainID string) string {
+	switch {
+	case lastStateID+1 != eventRecord.ID:
+		return fmt.Sprintf("id %d isn't sequential, expected %d", eventRecord.ID, lastStateID+1)
+	case eventRecord.ChainID != chainID:
+		return fmt.Sprintf("chain id %s doesn't match %s", eventRecord.ChainID, chainID)
+	case !eventRecord.Time.Before(to):
+		return fmt.Sprintf("emitted at %s, not before %s", eventRecord.Time.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	return ""
+}
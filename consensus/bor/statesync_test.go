@@ -0,0 +1,272 @@
+package bor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/tests/bor/mocks"
+)
+
+func TestSimulateStateSync(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		header   = &types.Header{Number: big.NewInt(8), Time: 1000}
+		to       = time.Unix(1000-10, 0)
+		contract = common.HexToAddress("0x1")
+	)
+
+	event := func(id uint64, chainID string) *clerk.EventRecordWithTime {
+		return &clerk.EventRecordWithTime{
+			EventRecord: clerk.EventRecord{ID: id, Contract: contract, ChainID: chainID},
+			Time:        to.Add(-time.Second),
+		}
+	}
+
+	heimdallClient := mocks.NewMockIHeimdallClient(ctrl)
+	heimdallClient.EXPECT().StateSyncEvents(gomock.Any(), uint64(5), to.Unix()).Return([]*clerk.EventRecordWithTime{
+		event(4, "137"),
+		event(5, "137"),
+		event(6, "80001"),
+		event(7, "137"),
+	}, nil)
+
+	genesisContracts := NewMockGenesisContract(ctrl)
+	genesisContracts.EXPECT().LastStateId(gomock.Any(), uint64(7), header.ParentHash).Return(big.NewInt(4), nil)
+	genesisContracts.EXPECT().CommitState(gomock.Any(), gomock.Any(), header, gomock.Any()).DoAndReturn(
+		func(event *clerk.EventRecordWithTime, state *state.StateDB, _ *types.Header, _ statefull.ChainContext) (uint64, error) {
+			state.AddLog(&types.Log{Address: event.Contract})
+			return 100, nil
+		})
+
+	// The block isn't the start of the last sprint of the span, no span is committed
+	spanner := NewMockSpanner(ctrl)
+	spanner.EXPECT().GetCurrentSpan(gomock.Any(), header.ParentHash).Return(&span.Span{ID: 1, StartBlock: 0, EndBlock: 255}, nil)
+
+	b := &Bor{
+		chainConfig: &params.ChainConfig{ChainID: big.NewInt(137)},
+		config: &params.BorConfig{
+			Sprint:                     map[string]uint64{"0": 4},
+			IndoreBlock:                common.Big0,
+			StateSyncConfirmationDelay: map[string]uint64{"0": 10},
+		},
+		spanner:                spanner,
+		HeimdallClient:         heimdallClient,
+		GenesisContractsClient: genesisContracts,
+	}
+
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+
+	simulation, err := b.SimulateStateSync(context.Background(), statedb, header, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(4), simulation.LastStateID)
+	require.Equal(t, to, simulation.To)
+	require.Equal(t, uint64(100), simulation.GasUsed)
+	require.Len(t, simulation.Records, 4)
+
+	require.False(t, simulation.Records[0].Committed)
+	require.Equal(t, "already committed", simulation.Records[0].Rejection)
+
+	require.True(t, simulation.Records[1].Committed)
+	require.Equal(t, uint64(100), simulation.Records[1].GasUsed)
+	require.Len(t, simulation.Records[1].Logs, 1)
+	require.Equal(t, contract, simulation.Records[1].Logs[0].Address)
+
+	require.False(t, simulation.Records[2].Committed)
+	require.Equal(t, "chain id 80001 doesn't match 137", simulation.Records[2].Rejection)
+
+	require.False(t, simulation.Records[3].Committed)
+	require.Equal(t, "not processed after the rejection of an earlier record", simulation.Records[3].Rejection)
+
+	// Nothing is written to the given state
+	require.Empty(t, statedb.Logs())
+
+	// Only the blocks starting a sprint commit state syncs
+	_, err = b.SimulateStateSync(context.Background(), statedb, &types.Header{Number: big.NewInt(9)}, nil)
+	require.Error(t, err)
+}
+
+// Tests that the next span is committed before the state syncs, as in Finalize,
+// and that a failed commit fails the whole simulation, as it fails the block.
+func TestSimulateStateSyncSpanStart(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		header  = &types.Header{Number: big.NewInt(12), Time: 1000}
+		to      = time.Unix(1000-10, 0)
+		spanned = common.HexToAddress("0x2")
+	)
+
+	// The block starts the last sprint of the span, the next span is committed
+	spanner := NewMockSpanner(ctrl)
+	spanner.EXPECT().GetCurrentSpan(gomock.Any(), header.ParentHash).Return(&span.Span{ID: 1, StartBlock: 0, EndBlock: 15}, nil)
+	spanner.EXPECT().CommitSpan(gomock.Any(), gomock.Any(), gomock.Any(), header, gomock.Any()).DoAndReturn(
+		func(_ context.Context, heimdallSpan span.HeimdallSpan, state *state.StateDB, _ *types.Header, _ core.ChainContext) error {
+			require.Equal(t, uint64(2), heimdallSpan.ID)
+			state.SetNonce(spanned, 1)
+
+			return nil
+		})
+
+	heimdallClient := mocks.NewMockIHeimdallClient(ctrl)
+	heimdallClient.EXPECT().Span(gomock.Any(), uint64(2)).Return(&span.HeimdallSpan{Span: span.Span{ID: 2, StartBlock: 16, EndBlock: 31}, ChainID: "137"}, nil)
+	heimdallClient.EXPECT().StateSyncEvents(gomock.Any(), uint64(5), to.Unix()).Return([]*clerk.EventRecordWithTime{
+		{EventRecord: clerk.EventRecord{ID: 5, ChainID: "137"}, Time: to.Add(-time.Second)},
+		{EventRecord: clerk.EventRecord{ID: 6, ChainID: "137"}, Time: to.Add(-time.Second)},
+	}, nil)
+
+	// The state syncs see the state of the committed span
+	genesisContracts := NewMockGenesisContract(ctrl)
+	genesisContracts.EXPECT().LastStateId(gomock.Any(), uint64(11), header.ParentHash).DoAndReturn(
+		func(state *state.StateDB, _ uint64, _ common.Hash) (*big.Int, error) {
+			require.Equal(t, uint64(1), state.GetNonce(spanned))
+			return big.NewInt(4), nil
+		})
+	gomock.InOrder(
+		genesisContracts.EXPECT().CommitState(gomock.Any(), gomock.Any(), header, gomock.Any()).DoAndReturn(
+			func(_ *clerk.EventRecordWithTime, state *state.StateDB, _ *types.Header, _ statefull.ChainContext) (uint64, error) {
+				require.Equal(t, uint64(1), state.GetNonce(spanned))
+				return 100, nil
+			}),
+		genesisContracts.EXPECT().CommitState(gomock.Any(), gomock.Any(), header, gomock.Any()).Return(uint64(0), errors.New("out of gas")),
+	)
+
+	b := &Bor{
+		chainConfig: &params.ChainConfig{ChainID: big.NewInt(137)},
+		config: &params.BorConfig{
+			Sprint:                     map[string]uint64{"0": 4},
+			IndoreBlock:                common.Big0,
+			StateSyncConfirmationDelay: map[string]uint64{"0": 10},
+		},
+		spanner:                spanner,
+		HeimdallClient:         heimdallClient,
+		GenesisContractsClient: genesisContracts,
+	}
+
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+
+	_, err = b.SimulateStateSync(context.Background(), statedb, header, nil)
+	require.ErrorContains(t, err, "failed to commit state sync 6: out of gas")
+
+	// Nothing is written to the given state
+	require.Zero(t, statedb.GetNonce(spanned))
+}
+
+func TestCommitStatesBudget(t *testing.T) {
+	t.Parallel()
+
+	const lastEvent = 12
+
+	// commit commits the state syncs of the block, after lastStateID, out of
+	// the events up to lastEvent. It returns the ids of the committed ones.
+	commit := func(t *testing.T, config *params.BorConfig, number int64, lastStateID int64) []uint64 {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		heimdallClient := mocks.NewMockIHeimdallClient(ctrl)
+		heimdallClient.EXPECT().StateSyncEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, fromID uint64, _ int64) ([]*clerk.EventRecordWithTime, error) {
+				events := make([]*clerk.EventRecordWithTime, 0)
+				for id := fromID; id <= lastEvent; id++ {
+					events = append(events, &clerk.EventRecordWithTime{EventRecord: clerk.EventRecord{ID: id, ChainID: "137"}})
+				}
+
+				return events, nil
+			})
+
+		genesisContracts := NewMockGenesisContract(ctrl)
+		genesisContracts.EXPECT().LastStateId(gomock.Any(), gomock.Any(), gomock.Any()).Return(big.NewInt(lastStateID), nil)
+		genesisContracts.EXPECT().CommitState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(100), nil).AnyTimes()
+
+		b := &Bor{
+			chainConfig:            &params.ChainConfig{ChainID: big.NewInt(137)},
+			config:                 config,
+			HeimdallClient:         heimdallClient,
+			GenesisContractsClient: genesisContracts,
+		}
+
+		statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		require.NoError(t, err)
+
+		stateSyncs, err := b.CommitStates(context.Background(), statedb, &types.Header{Number: big.NewInt(number), Time: 1000}, statefull.ChainContext{})
+		require.NoError(t, err)
+
+		ids := make([]uint64, 0, len(stateSyncs))
+		for _, stateSync := range stateSyncs {
+			ids = append(ids, stateSync.ID)
+		}
+
+		return ids
+	}
+
+	newConfig := func(gas uint64, records uint64) *params.BorConfig {
+		return &params.BorConfig{
+			Sprint:                     map[string]uint64{"0": 4},
+			IndoreBlock:                common.Big0,
+			StateSyncConfirmationDelay: map[string]uint64{"0": 0},
+			StateSyncBudgetBlock:       big.NewInt(8),
+			StateSyncGasBudget:         map[string]uint64{"0": gas},
+			StateSyncRecordBudget:      map[string]uint64{"0": records},
+		}
+	}
+
+	t.Run("records", func(t *testing.T) {
+		t.Parallel()
+
+		config := newConfig(0, 2)
+
+		// Every record is committed before the fork
+		require.Equal(t, []uint64{5, 6, 7, 8, 9, 10, 11, 12}, commit(t, config, 4, 4))
+
+		// The remainder of a sprint is carried over to the next ones, each of
+		// them starting after the last record committed by the previous one
+		lastStateID := int64(4)
+
+		for i, want := range [][]uint64{{5, 6}, {7, 8}, {9, 10}, {11, 12}, {}} {
+			ids := commit(t, config, int64(8+4*i), lastStateID)
+			require.Equal(t, want, ids)
+
+			if len(ids) > 0 {
+				lastStateID = int64(ids[len(ids)-1])
+			}
+		}
+	})
+
+	t.Run("gas", func(t *testing.T) {
+		t.Parallel()
+
+		// The commit reaching the budget is the last one
+		require.Equal(t, []uint64{5, 6}, commit(t, newConfig(200, 0), 8, 4))
+		require.Equal(t, []uint64{5, 6, 7}, commit(t, newConfig(201, 0), 8, 4))
+		require.Equal(t, []uint64{5}, commit(t, newConfig(1, 0), 8, 4))
+
+		// The first limit reached applies
+		require.Equal(t, []uint64{5, 6}, commit(t, newConfig(1000, 2), 8, 4))
+	})
+}
@@ -246,6 +246,7 @@ type BlockChain struct {
 	stateSyncData    []*types.StateSyncData                  // State sync data
 	stateSyncFeed    event.Feed                              // State sync feed
 	chain2HeadFeed   event.Feed                              // Reorg/NewHead/Fork data feed
+	txDependencies   *txDependencyTracker                    // Verification of the transaction dependencies declared by the producers
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -303,6 +304,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		vmConfig:      vmConfig,
 
 		borReceiptsCache: lru.NewCache[common.Hash, *types.Receipt](receiptsCacheLimit),
+		txDependencies:   newTxDependencyTracker(),
 	}
 	bc.flushInterval.Store(int64(cacheConfig.TrieTimeLimit))
 	bc.forker = NewForkChoice(bc, shouldPreserve, checker)
@@ -591,6 +593,12 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 	return result.receipts, result.logs, result.usedGas, result.statedb, result.err
 }
 
+// TxDependencyStats returns, per producer, the outcome of the verification of
+// the transaction dependencies declared in the imported blocks.
+func (bc *BlockChain) TxDependencyStats() map[common.Address]TxDependencyStats {
+	return bc.txDependencies.stats()
+}
+
 // empty returns an indicator whether the blockchain is empty.
 // Note, it's a special case that we connect a non-empty ancient
 // database with an empty node, so that we can plugin the ancient
@@ -1655,6 +1663,9 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 		}
 	}
 
+	// Index the state syncs committed in the block
+	bc.writeStateSyncRecords(blockBatch, block, state.StateSyncs())
+
 	rawdb.WritePreimages(blockBatch, state.Preimages())
 
 	if err := blockBatch.Write(); err != nil {
@@ -1830,7 +1841,7 @@ func (bc *BlockChain) writeBlockAndSetHead(ctx context.Context, block *types.Blo
 		if emitHeadEvent {
 			bc.chainHeadFeed.Send(ChainHeadEvent{Block: block})
 			// BOR state sync feed related changes
-			for _, data := range bc.stateSyncData {
+			for _, data := range state.StateSyncs() {
 				bc.stateSyncFeed.Send(StateSyncEvent{Data: data})
 			}
 			// BOR
@@ -2204,7 +2215,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals, setHead bool)
 		}
 
 		// BOR state sync feed related changes
-		for _, data := range bc.stateSyncData {
+		for _, data := range statedb.StateSyncs() {
 			bc.stateSyncFeed.Send(StateSyncEvent{Data: data})
 		}
 		// BOR
@@ -164,6 +164,15 @@ type ParallelExecutionResult struct {
 	Stats   *map[int]ExecutionStat
 	Deps    *DAG
 	AllDeps map[int]map[int]bool
+
+	// Number of incarnations executed by each transaction
+	Incarnations []int
+
+	// Dependencies reported by the aborted executions of each transaction, -1 when unknown
+	AbortDeps [][]int
+
+	// Number of failed validations of each transaction
+	ValidationFailures []int
 }
 
 const numGoProcs = 1
@@ -219,6 +228,10 @@ type ParallelExecutor struct {
 
 	diagExecSuccess, diagExecAbort []int
 
+	// Dependencies reported by the ErrExecAbortError of each task, and failed validations of each task
+	diagAbortDeps      [][]int
+	diagValidationFail []int
+
 	// Multi-version hash map
 	mvh *MVHashMap
 
@@ -240,6 +253,12 @@ type ParallelExecutor struct {
 	// Enable profiling
 	profile bool
 
+	// Predicts the conflicts between the transactions from the recent blocks, nil to schedule them by index only
+	predictor *ConflictPredictor
+
+	// Number of transactions scheduled after earlier ones because of predicted conflicts
+	cntSerialized int
+
 	// Worker wait group
 	workerWg sync.WaitGroup
 }
@@ -283,6 +302,8 @@ func NewParallelExecutor(tasks []ExecTask, profile bool, metadata bool, numProcs
 		validateTasks:       makeStatusManager(0),
 		diagExecSuccess:     make([]int, numTasks),
 		diagExecAbort:       make([]int, numTasks),
+		diagAbortDeps:       make([][]int, numTasks),
+		diagValidationFail:  make([]int, numTasks),
 		mvh:                 MakeMVHashMap(),
 		lastTxIO:            MakeTxnInputOutput(numTasks),
 		txIncarnations:      make([]int, numTasks),
@@ -299,6 +320,11 @@ func NewParallelExecutor(tasks []ExecTask, profile bool, metadata bool, numProcs
 func (pe *ParallelExecutor) Prepare() error {
 	prevSenderTx := make(map[common.Address]int)
 
+	var predicted [][]int
+	if pe.predictor != nil {
+		predicted = pe.predictor.Schedule(pe.tasks)
+	}
+
 	for i, t := range pe.tasks {
 		clearPendingFlag := false
 
@@ -324,6 +350,18 @@ func (pe *ParallelExecutor) Prepare() error {
 			}
 
 			prevSenderTx[t.Sender()] = i
+
+			if predicted != nil && len(predicted[i]) > 0 {
+				for _, dep := range predicted[i] {
+					pe.execTasks.addDependencies(dep, i)
+				}
+
+				if pe.execTasks.isBlocked(i) {
+					pe.execTasks.clearPending(i)
+				}
+
+				pe.cntSerialized++
+			}
 		}
 	}
 
@@ -465,6 +503,7 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 
 		pe.txIncarnations[tx]++
 		pe.diagExecAbort[tx]++
+		pe.diagAbortDeps[tx] = append(pe.diagAbortDeps[tx], execErr.Dependency)
 		pe.cntAbort++
 	} else {
 		pe.lastTxIO.recordRead(tx, res.txIn)
@@ -526,6 +565,7 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 			pe.cntValidationFail++
 
 			pe.diagExecAbort[tx]++
+			pe.diagValidationFail[tx]++
 			for _, v := range pe.lastTxIO.AllWriteSet(tx) {
 				pe.mvh.MarkEstimate(v.Path, tx)
 			}
@@ -567,7 +607,12 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 			deps = BuildDAG(*pe.lastTxIO)
 		}
 
-		return ParallelExecutionResult{pe.lastTxIO, &pe.stats, &deps, allDeps}, err
+		incarnations := make([]int, len(pe.tasks))
+		for i, n := range pe.txIncarnations {
+			incarnations[i] = n + 1
+		}
+
+		return ParallelExecutionResult{pe.lastTxIO, &pe.stats, &deps, allDeps, incarnations, pe.diagAbortDeps, pe.diagValidationFail}, err
 	}
 
 	// Send the next immediate pending transaction to be executed
@@ -601,12 +646,18 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 
 type PropertyCheck func(*ParallelExecutor) error
 
-func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyCheck, metadata bool, numProcs int, interruptCtx context.Context) (result ParallelExecutionResult, err error) {
+func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyCheck, metadata bool, numProcs int, predictor *ConflictPredictor, interruptCtx context.Context) (result ParallelExecutionResult, err error) {
 	if len(tasks) == 0 {
-		return ParallelExecutionResult{MakeTxnInputOutput(len(tasks)), nil, nil, nil}, nil
+		return ParallelExecutionResult{MakeTxnInputOutput(len(tasks)), nil, nil, nil, nil, nil, nil}, nil
 	}
 
 	pe := NewParallelExecutor(tasks, profile, metadata, numProcs)
+
+	// The dependencies given by the metadata don't need to be predicted
+	if !metadata {
+		pe.predictor = predictor
+	}
+
 	err = pe.Prepare()
 
 	if err != nil {
@@ -632,6 +683,10 @@ func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyChec
 			err = check(pe)
 		}
 
+		if result.TxIO != nil && err == nil {
+			pe.recordSchedule(metadata, predictor, result.TxIO)
+		}
+
 		if result.TxIO != nil || err != nil {
 			return result, err
 		}
@@ -640,6 +695,33 @@ func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyChec
 	return
 }
 
+// recordSchedule reports how well the transactions of the block were scheduled,
+// and lets the predictor learn their conflicts.
+func (pe *ParallelExecutor) recordSchedule(metadata bool, predictor *ConflictPredictor, txio *TxnInputOutput) {
+	if !metadata {
+		reexecutions := int64((pe.cntExec - len(pe.tasks)) * 100 / len(pe.tasks))
+
+		if pe.predictor != nil {
+			adaptiveReexecutionHistogram.Update(reexecutions)
+		} else {
+			indexReexecutionHistogram.Update(reexecutions)
+		}
+
+		serializedTxMeter.Mark(int64(pe.cntSerialized))
+	}
+
+	if predictor != nil {
+		predictor.Record(pe.tasks, txio)
+	}
+}
+
 func ExecuteParallel(tasks []ExecTask, profile bool, metadata bool, numProcs int, interruptCtx context.Context) (result ParallelExecutionResult, err error) {
-	return executeParallelWithCheck(tasks, profile, nil, metadata, numProcs, interruptCtx)
+	return executeParallelWithCheck(tasks, profile, nil, metadata, numProcs, nil, interruptCtx)
+}
+
+// ExecuteParallelAdaptive executes the tasks like ExecuteParallel, but executes
+// the ones the predictor expects to conflict one after the other. The predictor
+// then learns the conflicts of the tasks.
+func ExecuteParallelAdaptive(tasks []ExecTask, profile bool, metadata bool, numProcs int, predictor *ConflictPredictor, interruptCtx context.Context) (result ParallelExecutionResult, err error) {
+	return executeParallelWithCheck(tasks, profile, nil, metadata, numProcs, predictor, interruptCtx)
 }
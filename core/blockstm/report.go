@@ -0,0 +1,227 @@
+package blockstm
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ExecutionReport describes the parallel execution of the transactions of a
+// block: what they depend on, how often they had to be executed again, and how
+// much of the block could run in parallel.
+type ExecutionReport struct {
+	Transactions    []TxExecutionReport `json:"transactions"`
+	CriticalPath    []int               `json:"criticalPath"`
+	CriticalPathGas uint64              `json:"criticalPathGas"`
+	SerialGas       uint64              `json:"serialGas"`
+	HotKeys         []KeyConflicts      `json:"hotKeys"`
+}
+
+// TxExecutionReport describes the parallel execution of a transaction.
+type TxExecutionReport struct {
+	Index              int         `json:"index"`
+	Hash               common.Hash `json:"hash"`
+	Gas                uint64      `json:"gas"`
+	Dependencies       []int       `json:"dependencies"`
+	Incarnations       int         `json:"incarnations"`
+	Aborts             []int       `json:"aborts"`
+	ValidationFailures int         `json:"validationFailures"`
+}
+
+// KeyConflicts counts the dependencies between transactions caused by a key,
+// that is the reads of a value written by an earlier transaction of the block.
+type KeyConflicts struct {
+	Address      common.Address `json:"address"`
+	Slot         *common.Hash   `json:"slot,omitempty"`
+	Subpath      *uint8         `json:"subpath,omitempty"`
+	Reads        int            `json:"reads"`
+	Transactions int            `json:"transactions"`
+}
+
+// NewExecutionReport creates the report of a parallel execution from its result,
+// which must have been produced with profiling enabled, and the gas used by each
+// transaction. At most hotKeys keys are reported, the ones causing the most
+// dependencies first.
+func NewExecutionReport(tasks []ExecTask, result ParallelExecutionResult, gas []uint64, hotKeys int) *ExecutionReport {
+	report := &ExecutionReport{
+		Transactions: make([]TxExecutionReport, len(tasks)),
+		CriticalPath: make([]int, 0),
+		HotKeys:      make([]KeyConflicts, 0),
+	}
+
+	for i, task := range tasks {
+		tx := TxExecutionReport{
+			Index:        i,
+			Hash:         task.Hash(),
+			Gas:          gas[i],
+			Dependencies: make([]int, 0),
+			Aborts:       make([]int, 0),
+		}
+
+		if result.Incarnations != nil {
+			tx.Incarnations = result.Incarnations[i]
+			tx.Aborts = append(tx.Aborts, result.AbortDeps[i]...)
+			tx.ValidationFailures = result.ValidationFailures[i]
+		}
+
+		report.Transactions[i] = tx
+		report.SerialGas += gas[i]
+	}
+
+	if result.Deps != nil && result.Deps.DAG != nil {
+		for i, parents := range result.Deps.parents(len(tasks)) {
+			report.Transactions[i].Dependencies = parents
+		}
+
+		report.CriticalPath, report.CriticalPathGas = result.Deps.CriticalPath(gas)
+	}
+
+	if result.TxIO != nil {
+		report.HotKeys = hottestKeys(result.TxIO, hotKeys)
+	}
+
+	return report
+}
+
+// parents returns the transactions each of the n transactions of the block
+// directly depends on, in ascending order.
+func (d DAG) parents(n int) [][]int {
+	ids := make(map[int]string, n)
+
+	for id, v := range d.GetVertices() {
+		ids[v.(int)] = id
+	}
+
+	parents := make([][]int, n)
+
+	for i := 0; i < n; i++ {
+		parents[i] = make([]int, 0)
+
+		id, ok := ids[i]
+		if !ok {
+			continue
+		}
+
+		vertices, _ := d.GetParents(id)
+		for _, v := range vertices {
+			parents[i] = append(parents[i], v.(int))
+		}
+
+		sort.Ints(parents[i])
+	}
+
+	return parents
+}
+
+// CriticalPath finds the heaviest chain of dependent transactions, given the
+// weight of each transaction of the block. Unlike LongestPath, transactions
+// without any dependency don't need to be part of the DAG.
+func (d DAG) CriticalPath(weights []uint64) ([]int, uint64) {
+	var (
+		parents    = d.parents(len(weights))
+		prev       = make([]int, len(weights))
+		pathWeight = make([]uint64, len(weights))
+		maxPath    = -1
+		maxWeight  = uint64(0)
+	)
+
+	// Dependencies always point to earlier transactions
+	for i := range weights {
+		prev[i] = -1
+
+		for _, p := range parents[i] {
+			if pathWeight[p] > pathWeight[i] {
+				pathWeight[i] = pathWeight[p]
+				prev[i] = p
+			}
+		}
+
+		pathWeight[i] += weights[i]
+
+		if maxPath == -1 || pathWeight[i] > maxWeight {
+			maxPath = i
+			maxWeight = pathWeight[i]
+		}
+	}
+
+	path := make([]int, 0)
+	for i := maxPath; i != -1; i = prev[i] {
+		path = append(path, i)
+	}
+
+	// Reverse the path so the transactions are in the ascending order
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path, maxWeight
+}
+
+// hottestKeys counts, for every key, the reads of values written by earlier
+// transactions of the block, and returns the limit keys read the most.
+func hottestKeys(txio *TxnInputOutput, limit int) []KeyConflicts {
+	type conflicts struct {
+		reads int
+		txs   map[int]struct{}
+	}
+
+	counts := make(map[Key]*conflicts)
+
+	for i, inputs := range txio.inputs {
+		for _, rd := range inputs {
+			if rd.Kind != ReadKindMap || rd.V.TxnIndex >= i {
+				continue
+			}
+
+			c, ok := counts[rd.Path]
+			if !ok {
+				c = &conflicts{txs: make(map[int]struct{})}
+				counts[rd.Path] = c
+			}
+
+			c.reads++
+			c.txs[i] = struct{}{}
+		}
+	}
+
+	keys := make([]Key, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		ci, cj := counts[keys[i]], counts[keys[j]]
+		if ci.reads != cj.reads {
+			return ci.reads > cj.reads
+		}
+
+		return string(keys[i][:]) < string(keys[j][:])
+	})
+
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	hot := make([]KeyConflicts, 0, len(keys))
+
+	for _, k := range keys {
+		kc := KeyConflicts{
+			Address:      k.GetAddress(),
+			Reads:        counts[k].reads,
+			Transactions: len(counts[k].txs),
+		}
+
+		switch {
+		case k.IsState():
+			slot := k.GetStateKey()
+			kc.Slot = &slot
+		case k.IsSubpath():
+			subpath := k.GetSubpath()
+			kc.Subpath = &subpath
+		}
+
+		hot = append(hot, kc)
+	}
+
+	return hot
+}
@@ -0,0 +1,59 @@
+package blockstm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestExecutionReport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a = NewStateKey(common.BigToAddress(big.NewInt(1)), common.Hash{0x1})
+		b = NewStateKey(common.BigToAddress(big.NewInt(2)), common.Hash{0x2})
+		c = NewSubpathKey(common.BigToAddress(big.NewInt(3)), 1)
+	)
+
+	// 0 <- 1 <- 2 form a chain, 3 is independent
+	ops := [][]Op{
+		{{opType: writeType, key: a, val: 1}},
+		{{opType: otherType}, {opType: readType, key: a}, {opType: writeType, key: b, val: 1}},
+		{{opType: otherType}, {opType: readType, key: a}, {opType: readType, key: b}, {opType: writeType, key: b, val: 2}},
+		{{opType: otherType}, {opType: readType, key: c}, {opType: writeType, key: c, val: 1}},
+	}
+
+	tasks := make([]ExecTask, 0, len(ops))
+	for i, o := range ops {
+		tasks = append(tasks, NewTestExecTask(i, o, common.BigToAddress(big.NewInt(int64(100+i))), 0))
+	}
+
+	result, err := ExecuteParallel(tasks, true, false, numProcs, nil)
+	require.NoError(t, err)
+
+	report := NewExecutionReport(tasks, result, []uint64{10, 20, 30, 5}, 1)
+
+	require.Len(t, report.Transactions, 4)
+	assert.Equal(t, []int{}, report.Transactions[0].Dependencies)
+	assert.Equal(t, []int{0}, report.Transactions[1].Dependencies)
+	assert.Equal(t, []int{0, 1}, report.Transactions[2].Dependencies)
+	assert.Equal(t, []int{}, report.Transactions[3].Dependencies)
+
+	for _, tx := range report.Transactions {
+		assert.GreaterOrEqual(t, tx.Incarnations, 1)
+		assert.Equal(t, tx.Incarnations-1, len(tx.Aborts)+tx.ValidationFailures)
+	}
+
+	assert.Equal(t, []int{0, 1, 2}, report.CriticalPath)
+	assert.Equal(t, uint64(60), report.CriticalPathGas)
+	assert.Equal(t, uint64(65), report.SerialGas)
+
+	require.Len(t, report.HotKeys, 1)
+	assert.Equal(t, a.GetAddress(), report.HotKeys[0].Address)
+	assert.Equal(t, 2, report.HotKeys[0].Reads)
+	assert.Equal(t, 2, report.HotKeys[0].Transactions)
+}
@@ -0,0 +1,164 @@
+package blockstm
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	// Share of the executions of the transactions of a block which were re-executions, in percent,
+	// when scheduling the transactions by index only, and when predicting their conflicts
+	indexReexecutionHistogram    = metrics.NewRegisteredHistogram("blockstm/reexecutions/index", nil, metrics.NewExpDecaySample(1028, 0.015))
+	adaptiveReexecutionHistogram = metrics.NewRegisteredHistogram("blockstm/reexecutions/adaptive", nil, metrics.NewExpDecaySample(1028, 0.015))
+
+	serializedTxMeter = metrics.NewRegisteredMeter("blockstm/scheduler/serialized", nil)
+	hotKeysGauge      = metrics.NewRegisteredGauge("blockstm/scheduler/hotkeys", nil)
+)
+
+// An AccessHinter is an ExecTask knowing some of the keys it accesses before
+// being executed, such as the contract a transaction calls and its access list.
+// Address keys stand for any key of the address.
+type AccessHinter interface {
+	AccessHint() []Key
+}
+
+// A ConflictPredictor remembers the keys which caused conflicts between the
+// transactions of the recent blocks, so that the transactions likely to access
+// them can be executed one after the other instead of speculatively.
+type ConflictPredictor struct {
+	window    int // Number of blocks the conflicts are remembered for
+	threshold int // Number of conflicts over the window making a key hot
+
+	history []map[Key]int          // Conflicts caused by each key in the recent blocks, the oldest first
+	keys    map[Key]int            // Conflicts caused by each key over the window
+	addrs   map[common.Address]int // Conflicts caused by the keys of each address over the window
+
+	lock sync.RWMutex
+}
+
+// NewConflictPredictor creates a predictor remembering the conflicts of the
+// last window blocks, and considering a key hot once it caused threshold of them.
+func NewConflictPredictor(window int, threshold int) *ConflictPredictor {
+	return &ConflictPredictor{
+		window:    window,
+		threshold: threshold,
+		keys:      make(map[Key]int),
+		addrs:     make(map[common.Address]int),
+	}
+}
+
+// Record learns the conflicts between the transactions of an executed block,
+// that is the reads of values written by earlier transactions of the block.
+// Conflicts between the transactions of a sender are left out, as they are
+// always executed in order.
+func (p *ConflictPredictor) Record(tasks []ExecTask, txio *TxnInputOutput) {
+	conflicts := make(map[Key]int)
+
+	for i := range tasks {
+		for _, rd := range txio.ReadSet(i) {
+			j := rd.V.TxnIndex
+			if rd.Kind != ReadKindMap || j < 0 || j >= i || tasks[j].Sender() == tasks[i].Sender() {
+				continue
+			}
+
+			conflicts[rd.Path]++
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.history = append(p.history, conflicts)
+	p.update(conflicts, 1)
+
+	if len(p.history) > p.window {
+		p.update(p.history[0], -1)
+		p.history = p.history[1:]
+	}
+
+	hotKeysGauge.Update(int64(p.hotKeys()))
+}
+
+// update adds the conflicts of a block to the ones of the window, or removes
+// them when sign is negative.
+func (p *ConflictPredictor) update(conflicts map[Key]int, sign int) {
+	for k, n := range conflicts {
+		p.keys[k] += sign * n
+		if p.keys[k] == 0 {
+			delete(p.keys, k)
+		}
+
+		addr := k.GetAddress()
+
+		p.addrs[addr] += sign * n
+		if p.addrs[addr] == 0 {
+			delete(p.addrs, addr)
+		}
+	}
+}
+
+// hot reports whether a key caused enough conflicts recently, any key of the
+// address for an address key.
+func (p *ConflictPredictor) hot(k Key) bool {
+	if k.IsAddress() {
+		return p.addrs[k.GetAddress()] >= p.threshold
+	}
+
+	return p.keys[k] >= p.threshold
+}
+
+func (p *ConflictPredictor) hotKeys() int {
+	n := 0
+
+	for _, c := range p.keys {
+		if c >= p.threshold {
+			n++
+		}
+	}
+
+	return n
+}
+
+// HotKeys returns the number of keys which caused enough conflicts recently.
+func (p *ConflictPredictor) HotKeys() int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.hotKeys()
+}
+
+// Schedule predicts the conflicts between the tasks from their access hints.
+// It returns, for each task, the earlier tasks it should be executed after as
+// they access the same hot keys.
+func (p *ConflictPredictor) Schedule(tasks []ExecTask) [][]int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var (
+		deps = make([][]int, len(tasks))
+		last = make(map[Key]int)
+	)
+
+	for i, t := range tasks {
+		hinter, ok := t.(AccessHinter)
+		if !ok {
+			continue
+		}
+
+		for _, k := range hinter.AccessHint() {
+			if !p.hot(k) {
+				continue
+			}
+
+			if j, ok := last[k]; ok && tasks[j].Sender() != t.Sender() {
+				deps[i] = append(deps[i], j)
+			}
+
+			last[k] = i
+		}
+	}
+
+	return deps
+}
@@ -0,0 +1,99 @@
+package blockstm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type hintedExecTask struct {
+	*testExecTask
+	hint []Key
+}
+
+func (t *hintedExecTask) AccessHint() []Key {
+	return t.hint
+}
+
+// conflictingTasks creates tasks of different senders all incrementing the same
+// key, hinting the address of the key.
+func conflictingTasks(n int, k Key) []ExecTask {
+	tasks := make([]ExecTask, 0, n)
+
+	for i := 0; i < n; i++ {
+		ops := []Op{{opType: otherType}, {opType: readType, key: k}, {opType: writeType, key: k, val: i}}
+		task := NewTestExecTask(i, ops, common.BigToAddress(big.NewInt(int64(100+i))), 0)
+
+		tasks = append(tasks, &hintedExecTask{task, []Key{NewAddressKey(k.GetAddress())}})
+	}
+
+	return tasks
+}
+
+func TestConflictPredictor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		k         = NewStateKey(common.BigToAddress(big.NewInt(1)), common.Hash{0x1})
+		predictor = NewConflictPredictor(2, 4)
+	)
+
+	tasks := conflictingTasks(4, k)
+
+	result, err := ExecuteParallel(tasks, false, false, numProcs, nil)
+	require.NoError(t, err)
+
+	// 3 conflicts aren't enough for the key to be hot
+	predictor.Record(tasks, result.TxIO)
+	assert.Equal(t, 0, predictor.HotKeys())
+	assert.Equal(t, make([][]int, 4), predictor.Schedule(tasks))
+
+	predictor.Record(tasks, result.TxIO)
+	assert.Equal(t, 1, predictor.HotKeys())
+	assert.Equal(t, [][]int{nil, {0}, {1}, {2}}, predictor.Schedule(tasks))
+
+	// Tasks of the same sender are already executed in order
+	same := conflictingTasks(2, k)
+	same[1].(*hintedExecTask).sender = same[0].Sender()
+	assert.Equal(t, [][]int{nil, nil}, predictor.Schedule(same))
+
+	// The conflicts of the oldest block are forgotten
+	predictor.Record(tasks[:1], result.TxIO)
+	assert.Equal(t, 0, predictor.HotKeys())
+}
+
+func TestExecuteParallelAdaptive(t *testing.T) {
+	t.Parallel()
+
+	var (
+		k         = NewStateKey(common.BigToAddress(big.NewInt(1)), common.Hash{0x1})
+		predictor = NewConflictPredictor(16, 1)
+	)
+
+	for block := 0; block < 2; block++ {
+		var serialized int
+
+		tasks := conflictingTasks(10, k)
+
+		result, err := executeParallelWithCheck(tasks, true, func(pe *ParallelExecutor) error {
+			serialized = pe.cntSerialized
+			return nil
+		}, false, numProcs, predictor, nil)
+		require.NoError(t, err)
+
+		// The conflicts are learnt from the first block
+		if block == 0 {
+			assert.Equal(t, 0, serialized)
+		} else {
+			assert.Equal(t, 9, serialized)
+		}
+
+		for i := 1; i < len(tasks); i++ {
+			assert.Equal(t, map[int]bool{i - 1: true}, result.AllDeps[i])
+		}
+	}
+}
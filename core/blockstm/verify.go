@@ -0,0 +1,86 @@
+package blockstm
+
+// A MissingDependency is a dependency between two transactions observed while
+// executing them, which isn't implied by the dependencies declared in the block:
+// the transaction To read a value written by the transaction From.
+type MissingDependency struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// VerifyDependencies compares the dependencies between the transactions
+// declared by the producer of a block with the DAG observed while executing
+// them. Declared dependencies may be transitive, but the observed edges which
+// can't be reached through them are returned, in the ascending order. The
+// accesses to the ignored keys don't make dependencies.
+func VerifyDependencies(declared [][]uint64, txio *TxnInputOutput, ignored ...Key) []MissingDependency {
+	var (
+		n         = len(txio.inputs)
+		ancestors = make([]map[int]struct{}, n)
+		missing   = make([]MissingDependency, 0)
+	)
+
+	// Dependencies always point to earlier transactions, anything else is ignored
+	for i := 0; i < n; i++ {
+		ancestors[i] = make(map[int]struct{})
+
+		if i >= len(declared) {
+			continue
+		}
+
+		for _, dep := range declared[i] {
+			if dep >= uint64(i) {
+				continue
+			}
+
+			j := int(dep)
+
+			ancestors[i][j] = struct{}{}
+			for k := range ancestors[j] {
+				ancestors[i][k] = struct{}{}
+			}
+		}
+	}
+
+	if len(ignored) > 0 {
+		txio = withoutKeys(txio, ignored)
+	}
+
+	for i, parents := range BuildDAG(*txio).parents(n) {
+		for _, j := range parents {
+			if _, ok := ancestors[i][j]; !ok {
+				missing = append(missing, MissingDependency{From: j, To: i})
+			}
+		}
+	}
+
+	return missing
+}
+
+// withoutKeys returns the reads and writes of the transactions, leaving out the
+// ones of the given keys.
+func withoutKeys(txio *TxnInputOutput, keys []Key) *TxnInputOutput {
+	skip := make(map[Key]struct{}, len(keys))
+	for _, k := range keys {
+		skip[k] = struct{}{}
+	}
+
+	n := len(txio.inputs)
+	filtered := MakeTxnInputOutput(n)
+
+	for i := 0; i < n; i++ {
+		for _, rd := range txio.inputs[i] {
+			if _, ok := skip[rd.Path]; !ok {
+				filtered.inputs[i] = append(filtered.inputs[i], rd)
+			}
+		}
+
+		for _, wd := range txio.allOutputs[i] {
+			if _, ok := skip[wd.Path]; !ok {
+				filtered.allOutputs[i] = append(filtered.allOutputs[i], wd)
+			}
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,58 @@
+package blockstm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestVerifyDependencies(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a = NewStateKey(common.BigToAddress(big.NewInt(1)), common.Hash{0x1})
+		b = NewStateKey(common.BigToAddress(big.NewInt(2)), common.Hash{0x2})
+		c = NewSubpathKey(common.BigToAddress(big.NewInt(3)), 1)
+	)
+
+	// 1 depends on 0, 2 on 0 and 1, 3 is independent
+	ops := [][]Op{
+		{{opType: writeType, key: a, val: 1}},
+		{{opType: otherType}, {opType: readType, key: a}, {opType: writeType, key: b, val: 1}},
+		{{opType: otherType}, {opType: readType, key: a}, {opType: readType, key: b}, {opType: writeType, key: b, val: 2}},
+		{{opType: otherType}, {opType: readType, key: c}, {opType: writeType, key: c, val: 1}},
+	}
+
+	tasks := make([]ExecTask, 0, len(ops))
+	for i, o := range ops {
+		tasks = append(tasks, NewTestExecTask(i, o, common.BigToAddress(big.NewInt(int64(100+i))), 0))
+	}
+
+	result, err := ExecuteParallel(tasks, false, false, numProcs, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		declared [][]uint64
+		missing  []MissingDependency
+	}{
+		{"exact", [][]uint64{{}, {0}, {0, 1}, {}}, []MissingDependency{}},
+		{"transitive", [][]uint64{{}, {0}, {1}, {}}, []MissingDependency{}},
+		{"extra", [][]uint64{{}, {0}, {1}, {2}}, []MissingDependency{}},
+		{"missing", [][]uint64{{}, {0}, {}, {}}, []MissingDependency{{From: 0, To: 2}, {From: 1, To: 2}}},
+		{"short", [][]uint64{{}, {0}}, []MissingDependency{{From: 0, To: 2}, {From: 1, To: 2}}},
+		{"later", [][]uint64{{}, {0}, {3}, {}}, []MissingDependency{{From: 0, To: 2}, {From: 1, To: 2}}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.missing, VerifyDependencies(tt.declared, result.TxIO), tt.name)
+	}
+
+	// Without the accesses to a, 1 and 2 don't depend on 0 anymore
+	assert.Equal(t, []MissingDependency{}, VerifyDependencies([][]uint64{{}, {}, {1}, {}}, result.TxIO, a), "ignored")
+	assert.Equal(t, []MissingDependency{{From: 1, To: 2}}, VerifyDependencies([][]uint64{{}, {}, {}, {}}, result.TxIO, a), "ignored missing")
+}
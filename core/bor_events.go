@@ -1,7 +1,9 @@
 package core
 
 import (
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
 )
 
 // StateSyncEvent represents state sync events
@@ -21,3 +23,31 @@ type Chain2HeadEvent struct {
 	OldChain []*types.Block
 	Type     string
 }
+
+// writeStateSyncRecords indexes the state syncs committed while processing the
+// block, as recorded in its state. State syncs are only committed in the blocks
+// starting a sprint. The blocks inserted without being executed, e.g. by a snap
+// sync, aren't indexed: their bor receipts don't hold the payload of the state
+// syncs.
+func (bc *BlockChain) writeStateSyncRecords(db ethdb.KeyValueWriter, block *types.Block, stateSyncs []*types.StateSyncData) {
+	number := block.NumberU64()
+
+	if bor := bc.chainConfig.Bor; bor != nil && bor.Sprint != nil && !bor.IsSprintStart(number) {
+		return
+	}
+
+	borTxHash := types.GetDerivedBorTxHash(types.BorReceiptKey(number, block.Hash()))
+
+	for _, data := range stateSyncs {
+		rawdb.WriteStateSyncRecord(db, &types.StateSyncRecord{
+			ID:          data.ID,
+			Contract:    data.Contract,
+			Data:        data.Data,
+			L1TxHash:    data.TxHash,
+			BlockNumber: number,
+			BlockHash:   block.Hash(),
+			BorTxHash:   borTxHash,
+			LogIndex:    data.LogIndex,
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// stateSyncEngine commits the given state syncs while finalizing the blocks.
+type stateSyncEngine struct {
+	consensus.Engine
+	stateSyncs map[uint64][]*types.StateSyncData
+}
+
+func (e *stateSyncEngine) Finalize(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, uncles []*types.Header, withdrawals []*types.Withdrawal) {
+	e.Engine.Finalize(chain, header, state, txs, uncles, withdrawals)
+	state.SetStateSyncs(e.stateSyncs[header.Number.Uint64()])
+}
+
+func TestStateSyncRecords(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &Genesis{Config: params.TestChainConfig, BaseFee: common.Big0}
+		l1Tx  = common.HexToHash("0x1")
+	)
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 5, nil)
+
+	// The block starting the sprint commits two state syncs emitted by the same
+	// L1 transaction, the other ones can't commit any
+	engine := &stateSyncEngine{
+		Engine: ethash.NewFaker(),
+		stateSyncs: map[uint64][]*types.StateSyncData{
+			4: {
+				{ID: 1, Contract: common.HexToAddress("0xa"), Data: "01", TxHash: l1Tx},
+				{ID: 2, Contract: common.HexToAddress("0xb"), Data: "02", TxHash: l1Tx, LogIndex: 1},
+			},
+		},
+	}
+
+	chain, err := NewBlockChain(db, nil, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// The state syncs of the blocks assembled meanwhile aren't indexed
+	chain.SetStateSync([]*types.StateSyncData{{ID: 3, TxHash: l1Tx}})
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert block: %v", err)
+	}
+
+	record := rawdb.ReadStateSyncRecord(db, 2)
+	if record == nil {
+		t.Fatalf("state sync 2 not indexed")
+	}
+
+	want := &types.StateSyncRecord{
+		ID:          2,
+		Contract:    common.HexToAddress("0xb"),
+		Data:        "02",
+		L1TxHash:    l1Tx,
+		BlockNumber: 4,
+		BlockHash:   blocks[3].Hash(),
+		BorTxHash:   types.GetDerivedBorTxHash(types.BorReceiptKey(4, blocks[3].Hash())),
+		LogIndex:    1,
+	}
+	if *record != *want {
+		t.Fatalf("state sync record mismatch, have %+v, want %+v", record, want)
+	}
+
+	if ids := rawdb.ReadStateSyncIDsByL1TxHash(db, l1Tx); len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("L1 tx lookup mismatch, have %v, want [1 2]", ids)
+	}
+
+	if record := rawdb.ReadStateSyncRecord(db, 3); record != nil {
+		t.Fatalf("unknown state sync found: %+v", record)
+	}
+
+	// Records of blocks which aren't canonical are ignored
+	rawdb.WriteStateSyncRecord(db, &types.StateSyncRecord{ID: 3, BlockNumber: 4, BlockHash: common.HexToHash("0xdead")})
+
+	if record := rawdb.ReadStateSyncRecord(db, 3); record != nil {
+		t.Fatalf("state sync of a non canonical block found: %+v", record)
+	}
+}
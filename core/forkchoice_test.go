@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 )
@@ -237,8 +238,10 @@ func (w *chainValidatorFake) IsValidPeer(fetchHeadersByNumber func(number uint64
 func (w *chainValidatorFake) IsValidChain(current *types.Header, headers []*types.Header) (bool, error) {
 	return w.validate(current, headers)
 }
-func (w *chainValidatorFake) ProcessCheckpoint(endBlockNum uint64, endBlockHash common.Hash) {}
-func (w *chainValidatorFake) ProcessMilestone(endBlockNum uint64, endBlockHash common.Hash)  {}
+func (w *chainValidatorFake) ProcessCheckpoint(endBlockNum uint64, endBlockHash common.Hash, checkpointNumber uint64) {
+}
+func (w *chainValidatorFake) ProcessMilestone(endBlockNum uint64, endBlockHash common.Hash, milestoneID string) {
+}
 func (w *chainValidatorFake) ProcessFutureMilestone(num uint64, hash common.Hash) {
 }
 func (w *chainValidatorFake) GetWhitelistedCheckpoint() (bool, uint64, common.Hash) {
@@ -248,6 +251,15 @@ func (w *chainValidatorFake) GetWhitelistedCheckpoint() (bool, uint64, common.Ha
 func (w *chainValidatorFake) GetWhitelistedMilestone() (bool, uint64, common.Hash) {
 	return false, 0, common.Hash{}
 }
+func (w *chainValidatorFake) GetWhitelistedCheckpointNumber() uint64 {
+	return 0
+}
+func (w *chainValidatorFake) GetWhitelistedMilestoneID() string {
+	return ""
+}
+func (w *chainValidatorFake) SubscribeFinality(ch chan<- *types.FinalityBlock) event.Subscription {
+	return new(event.Feed).Subscribe(ch)
+}
 func (w *chainValidatorFake) PurgeWhitelistedCheckpoint() {}
 func (w *chainValidatorFake) PurgeWhitelistedMilestone()  {}
 func (w *chainValidatorFake) GetCheckpoints(current, sidechainHeader *types.Header, sidechainCheckpoints []*types.Header) (map[uint64]*types.Header, error) {
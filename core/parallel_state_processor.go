@@ -38,8 +38,14 @@ import (
 type ParallelEVMConfig struct {
 	Enable               bool
 	SpeculativeProcesses int
+	AdaptiveScheduling   bool
 }
 
+const (
+	conflictPredictorWindow    = 16 // Number of recent blocks the conflicts between transactions are learnt from
+	conflictPredictorThreshold = 4  // Number of recent conflicts making a key hot
+)
+
 // StateProcessor is a basic Processor, which takes care of transitioning
 // state from one point to another.
 //
@@ -48,14 +54,17 @@ type ParallelStateProcessor struct {
 	config *params.ChainConfig // Chain configuration options
 	bc     *BlockChain         // Canonical block chain
 	engine consensus.Engine    // Consensus engine used for block rewards
+
+	predictor *blockstm.ConflictPredictor // Conflicts of the recent blocks, used with adaptive scheduling
 }
 
 // NewParallelStateProcessor initialises a new StateProcessor.
 func NewParallelStateProcessor(config *params.ChainConfig, bc *BlockChain, engine consensus.Engine) *ParallelStateProcessor {
 	return &ParallelStateProcessor{
-		config: config,
-		bc:     bc,
-		engine: engine,
+		config:    config,
+		bc:        bc,
+		engine:    engine,
+		predictor: blockstm.NewConflictPredictor(conflictPredictorWindow, conflictPredictorThreshold),
 	}
 }
 
@@ -89,6 +98,15 @@ type ExecutionTask struct {
 	dependencies []int
 	coinbase     common.Address
 	blockContext vm.BlockContext
+
+	// When building a block, invalid transactions are skipped instead of failing
+	// the execution. invalid holds the reason the last incarnation was skipped.
+	skipInvalid bool
+	invalid     error
+
+	// When replaying a block, prestate is called with the state before the
+	// transaction once it is settled
+	prestate func(int, *state.StateDB)
 }
 
 func (task *ExecutionTask) Execute(mvh *blockstm.MVHashMap, incarnation int) (err error) {
@@ -97,6 +115,8 @@ func (task *ExecutionTask) Execute(mvh *blockstm.MVHashMap, incarnation int) (er
 	task.statedb.SetMVHashmap(mvh)
 	task.statedb.SetIncarnation(incarnation)
 
+	task.invalid = nil
+
 	evm := vm.NewEVM(task.blockContext, vm.TxContext{}, task.statedb, task.config, task.evmConfig)
 
 	// Create a new context to be used in the EVM environment.
@@ -119,6 +139,10 @@ func (task *ExecutionTask) Execute(mvh *blockstm.MVHashMap, incarnation int) (er
 		task.result, err = ApplyMessageNoFeeBurnOrTip(evm, task.msg, new(GasPool).AddGas(task.gasLimit), nil)
 
 		if task.result == nil || err != nil {
+			if task.skip(err) {
+				return nil
+			}
+
 			return blockstm.ErrExecAbortError{Dependency: task.statedb.DepTxIndex(), OriginError: err}
 		}
 
@@ -139,6 +163,10 @@ func (task *ExecutionTask) Execute(mvh *blockstm.MVHashMap, incarnation int) (er
 		task.result, err = ApplyMessage(evm, &task.msg, new(GasPool).AddGas(task.gasLimit), nil)
 	}
 
+	if task.skip(err) {
+		return nil
+	}
+
 	if task.statedb.HadInvalidRead() || err != nil {
 		err = blockstm.ErrExecAbortError{Dependency: task.statedb.DepTxIndex(), OriginError: err}
 		return
@@ -149,18 +177,62 @@ func (task *ExecutionTask) Execute(mvh *blockstm.MVHashMap, incarnation int) (er
 	return
 }
 
+// skip checks whether the transaction failed on its own, and not because of a
+// value that may still change, in which case it's skipped if allowed.
+func (task *ExecutionTask) skip(err error) bool {
+	if !task.skipInvalid || err == nil || task.statedb.HadInvalidRead() {
+		return false
+	}
+
+	task.invalid = err
+
+	return true
+}
+
 func (task *ExecutionTask) MVReadList() []blockstm.ReadDescriptor {
 	return task.statedb.MVReadList()
 }
 
+// The writes of a skipped transaction are discarded, its reads are still
+// validated in case it was skipped because of a stale value.
 func (task *ExecutionTask) MVWriteList() []blockstm.WriteDescriptor {
+	if task.invalid != nil {
+		return nil
+	}
+
 	return task.statedb.MVWriteList()
 }
 
 func (task *ExecutionTask) MVFullWriteList() []blockstm.WriteDescriptor {
+	if task.invalid != nil {
+		return nil
+	}
+
 	return task.statedb.MVFullWriteList()
 }
 
+// AccessHint returns the contract called by the transaction and the keys of its
+// access list.
+func (task *ExecutionTask) AccessHint() []blockstm.Key {
+	hint := make([]blockstm.Key, 0, 1+len(task.msg.AccessList))
+
+	if task.msg.To != nil {
+		hint = append(hint, blockstm.NewAddressKey(*task.msg.To))
+	}
+
+	for _, tuple := range task.msg.AccessList {
+		if len(tuple.StorageKeys) == 0 {
+			hint = append(hint, blockstm.NewAddressKey(tuple.Address))
+		}
+
+		for _, slot := range tuple.StorageKeys {
+			hint = append(hint, blockstm.NewStateKey(tuple.Address, slot))
+		}
+	}
+
+	return hint
+}
+
 func (task *ExecutionTask) Sender() common.Address {
 	return task.sender
 }
@@ -174,6 +246,19 @@ func (task *ExecutionTask) Dependencies() []int {
 }
 
 func (task *ExecutionTask) Settle() {
+	if task.invalid != nil {
+		return
+	}
+
+	if task.prestate != nil {
+		task.prestate(task.index, task.finalStateDB)
+	}
+
+	// Skipped transactions leave no gap in the block
+	if task.skipInvalid {
+		task.index = len(*task.receipts)
+	}
+
 	task.finalStateDB.SetTxContext(task.tx.Hash(), task.index)
 
 	coinbaseBalance := task.finalStateDB.GetBalance(task.coinbase)
@@ -261,8 +346,64 @@ var parallelizabilityTimer = metrics.NewRegisteredTimer("block/parallelizability
 // Process returns the receipts and logs accumulated during the process and
 // returns the amount of gas that was used in the process. If any of the
 // transactions failed to execute due to insufficient gas it will return an error.
-// nolint:gocognit
 func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context) (types.Receipts, []*types.Log, uint64, error) {
+	profile := false
+
+	receipts, allLogs, usedGas, _, result, err := p.process(block, statedb, cfg, profile, interruptCtx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if declared := block.GetTxDependency(); declared != nil {
+		if err := p.bc.verifyTxDependency(block, declared, result.TxIO); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	if profile && result.Deps != nil {
+		_, weight := result.Deps.LongestPath(*result.Stats)
+
+		serialWeight := uint64(0)
+
+		for i := 0; i < len(result.Deps.GetVertices()); i++ {
+			serialWeight += (*result.Stats)[i].End - (*result.Stats)[i].Start
+		}
+
+		parallelizabilityTimer.Update(time.Duration(serialWeight * 100 / weight))
+	}
+
+	return receipts, allLogs, usedGas, nil
+}
+
+// ExecutionReport re-executes the block on top of statedb, the state of its
+// parent, and reports how its transactions were executed in parallel, along
+// with the hotKeys keys causing the most dependencies between them.
+func (p *ParallelStateProcessor) ExecutionReport(block *types.Block, statedb *state.StateDB, cfg vm.Config, hotKeys int) (*blockstm.ExecutionReport, error) {
+	receipts, _, _, tasks, result, err := p.process(block, statedb, cfg, true, context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	gas := make([]uint64, len(tasks))
+	for i, receipt := range receipts {
+		gas[i] = receipt.GasUsed
+	}
+
+	return blockstm.NewExecutionReport(tasks, result, gas, hotKeys), nil
+}
+
+// executeParallel executes the tasks with Block-STM, predicting the conflicts
+// between them from the recent blocks when adaptive scheduling is enabled.
+func (p *ParallelStateProcessor) executeParallel(tasks []blockstm.ExecTask, profile bool, metadata bool, cfg vm.Config, interruptCtx context.Context) (blockstm.ParallelExecutionResult, error) {
+	if cfg.ParallelAdaptiveScheduling {
+		return blockstm.ExecuteParallelAdaptive(tasks, profile, metadata, cfg.ParallelSpeculativeProcesses, p.predictor, interruptCtx)
+	}
+
+	return blockstm.ExecuteParallel(tasks, profile, metadata, cfg.ParallelSpeculativeProcesses, interruptCtx)
+}
+
+// nolint:gocognit
+func (p *ParallelStateProcessor) process(block *types.Block, statedb *state.StateDB, cfg vm.Config, profile bool, interruptCtx context.Context) (types.Receipts, []*types.Log, uint64, []blockstm.ExecTask, blockstm.ParallelExecutionResult, error) {
 	var (
 		receipts    types.Receipts
 		header      = block.Header()
@@ -299,7 +440,7 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 		msg, err := TransactionToMessage(tx, types.MakeSigner(p.config, header.Number), header.BaseFee)
 		if err != nil {
 			log.Error("error creating message", "err", err)
-			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			return nil, nil, 0, nil, blockstm.ParallelExecutionResult{}, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 
 		cleansdb := statedb.Copy()
@@ -363,20 +504,7 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 
 	backupStateDB := statedb.Copy()
 
-	profile := false
-	result, err := blockstm.ExecuteParallel(tasks, profile, metadata, cfg.ParallelSpeculativeProcesses, interruptCtx)
-
-	if err == nil && profile && result.Deps != nil {
-		_, weight := result.Deps.LongestPath(*result.Stats)
-
-		serialWeight := uint64(0)
-
-		for i := 0; i < len(result.Deps.GetVertices()); i++ {
-			serialWeight += (*result.Stats)[i].End - (*result.Stats)[i].Start
-		}
-
-		parallelizabilityTimer.Update(time.Duration(serialWeight * 100 / weight))
-	}
+	result, err := p.executeParallel(tasks, profile, metadata, cfg, interruptCtx)
 
 	for _, task := range tasks {
 		task := task.(*ExecutionTask)
@@ -398,20 +526,20 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 				t.totalUsedGas = usedGas
 			}
 
-			_, err = blockstm.ExecuteParallel(tasks, false, metadata, cfg.ParallelSpeculativeProcesses, interruptCtx)
+			result, err = p.executeParallel(tasks, profile, metadata, cfg, interruptCtx)
 
 			break
 		}
 	}
 
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, 0, nil, result, err
 	}
 
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
 	p.engine.Finalize(p.bc, header, statedb, block.Transactions(), block.Uncles(), nil)
 
-	return receipts, allLogs, *usedGas, nil
+	return receipts, allLogs, *usedGas, tasks, result, nil
 }
 
 func GetDeps(txDependency [][]uint64) map[int][]int {
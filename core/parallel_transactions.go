@@ -0,0 +1,236 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/blockstm"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ErrParallelFeeDelay is returned by ApplyTransactionsParallel when one of the
+// transactions reads the balance the fees are paid to. The fees are only paid
+// once the transactions are settled, the transactions must be applied serially.
+var ErrParallelFeeDelay = errors.New("transaction reads the balance of the fee recipient")
+
+// ParallelTransactionsResult is the outcome of applying a batch of transactions
+// in parallel on top of a block being built.
+type ParallelTransactionsResult struct {
+	State    *state.StateDB // State after the included transactions
+	Receipts types.Receipts // Receipts of all the transactions of the block
+	GasUsed  uint64         // Gas used by all the transactions of the block
+
+	// Errors holds why each transaction of the batch was skipped, nil for the
+	// included ones. The reads and writes of each transaction are recorded to
+	// build the dependencies of the block.
+	Errors         []error
+	ReadLists      [][]blockstm.ReadDescriptor
+	FullWriteLists [][]blockstm.WriteDescriptor
+}
+
+// ApplyTransactionsParallel speculatively executes the transactions with
+// Block-STM on top of the state of a block being built, and settles them in the
+// given order. Invalid transactions are skipped, the other ones are applied to
+// a copy of statedb, and their receipts are appended to the ones of the block.
+// Transactions of the same sender must be given in nonce order.
+func ApplyTransactionsParallel(config *params.ChainConfig, bc *BlockChain, coinbase common.Address, statedb *state.StateDB, header *types.Header, receipts types.Receipts, txs []*types.Transaction, cfg vm.Config, interruptCtx context.Context) (*ParallelTransactionsResult, error) {
+	var (
+		work         = statedb.Copy()
+		clean        = statedb.Copy() // Shared by the tasks, which copy it on every execution
+		usedGas      = header.GasUsed
+		allReceipts  = append(types.Receipts{}, receipts...)
+		allLogs      []*types.Log
+		signer       = types.MakeSigner(config, header.Number)
+		blockContext = NewEVMBlockContext(header, bc, &coinbase)
+		tasks        = make([]blockstm.ExecTask, 0, len(txs))
+
+		shouldDelayFeeCal = true
+	)
+
+	for i, tx := range txs {
+		msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return nil, err
+		}
+
+		if msg.From == coinbase {
+			shouldDelayFeeCal = false
+		}
+
+		tasks = append(tasks, &ExecutionTask{
+			msg:               *msg,
+			config:            config,
+			gasLimit:          header.GasLimit,
+			blockNumber:       header.Number,
+			blockHash:         header.Hash(),
+			tx:                tx,
+			index:             i,
+			cleanStateDB:      clean,
+			finalStateDB:      work,
+			blockChain:        bc,
+			header:            header,
+			evmConfig:         cfg,
+			shouldDelayFeeCal: &shouldDelayFeeCal,
+			sender:            msg.From,
+			totalUsedGas:      &usedGas,
+			receipts:          &allReceipts,
+			allLogs:           &allLogs,
+			coinbase:          coinbase,
+			blockContext:      blockContext,
+			skipInvalid:       true,
+		})
+	}
+
+	result, err := blockstm.ExecuteParallel(tasks, false, false, cfg.ParallelSpeculativeProcesses, interruptCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ParallelTransactionsResult{
+		State:          work,
+		Receipts:       allReceipts,
+		GasUsed:        usedGas,
+		Errors:         make([]error, len(tasks)),
+		ReadLists:      make([][]blockstm.ReadDescriptor, len(tasks)),
+		FullWriteLists: make([][]blockstm.WriteDescriptor, len(tasks)),
+	}
+
+	for i, task := range tasks {
+		task := task.(*ExecutionTask)
+		if task.shouldRerunWithoutFeeDelay {
+			return nil, ErrParallelFeeDelay
+		}
+
+		res.Errors[i] = task.invalid
+		res.ReadLists[i] = result.TxIO.ReadSet(i)
+		res.FullWriteLists[i] = result.TxIO.AllWriteSet(i)
+	}
+
+	return res, nil
+}
+
+// IntermediateStatesParallel re-executes the first n transactions of a block
+// with Block-STM on top of the state of its parent, honouring the dependencies
+// declared in the block. onPrestate is called in order, from a single goroutine,
+// with the state before each of the transactions as soon as it is settled, and
+// then with the state after the last one. The states before the transactions
+// are modified once onPrestate returns, they must be copied to be retained. The
+// state after the last one is handed over to onPrestate. statedb itself isn't
+// modified. On error, onPrestate may have been called for the first states.
+func IntermediateStatesParallel(config *params.ChainConfig, chain ChainContext, block *types.Block, statedb *state.StateDB, n int, cfg vm.Config, interruptCtx context.Context, onPrestate func(int, *state.StateDB)) error {
+	var (
+		header       = block.Header()
+		txs          = block.Transactions()[:n]
+		signer       = types.MakeSigner(config, header.Number)
+		blockContext = NewEVMBlockContext(header, chain, nil)
+		msgs         = make([]*Message, len(txs))
+		deps         map[int][]int
+		metadata     bool
+
+		shouldDelayFeeCal = true
+	)
+
+	// Dependencies only point to earlier transactions, the ones of the block
+	// hold for any of its prefixes
+	if txDependency := block.GetTxDependency(); len(txDependency) == len(block.Transactions()) {
+		deps = GetDeps(txDependency)
+		metadata = true
+	}
+
+	for i, tx := range txs {
+		msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+		}
+
+		if msg.From == blockContext.Coinbase {
+			shouldDelayFeeCal = false
+		}
+
+		msgs[i] = msg
+	}
+
+	var (
+		clean = statedb.Copy() // Shared by the tasks of both executions
+
+		// The transactions preceding the first one reading the delayed fees are
+		// executed the same way without delaying them, the states before them
+		// aren't delivered again when the block is executed again.
+		delivered int
+		rerun     bool
+	)
+
+	execute := func() error {
+		var (
+			work     = statedb.Copy()
+			usedGas  uint64
+			receipts types.Receipts
+			allLogs  []*types.Log
+			tasks    = make([]blockstm.ExecTask, 0, len(txs))
+		)
+
+		prestate := func(index int, statedb *state.StateDB) {
+			if rerun || index < delivered {
+				return
+			}
+
+			onPrestate(index, statedb)
+			delivered = index + 1
+
+			rerun = tasks[index].(*ExecutionTask).shouldRerunWithoutFeeDelay
+		}
+
+		for i, tx := range txs {
+			tasks = append(tasks, &ExecutionTask{
+				msg:               *msgs[i],
+				config:            config,
+				gasLimit:          header.GasLimit,
+				blockNumber:       header.Number,
+				blockHash:         block.Hash(),
+				tx:                tx,
+				index:             i,
+				cleanStateDB:      clean,
+				finalStateDB:      work,
+				header:            header,
+				evmConfig:         cfg,
+				shouldDelayFeeCal: &shouldDelayFeeCal,
+				sender:            msgs[i].From,
+				totalUsedGas:      &usedGas,
+				receipts:          &receipts,
+				allLogs:           &allLogs,
+				dependencies:      deps[i],
+				coinbase:          blockContext.Coinbase,
+				blockContext:      blockContext,
+				prestate:          prestate,
+			})
+		}
+
+		if _, err := blockstm.ExecuteParallel(tasks, false, metadata, cfg.ParallelSpeculativeProcesses, interruptCtx); err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			if task.(*ExecutionTask).shouldRerunWithoutFeeDelay {
+				rerun = true
+				return nil
+			}
+		}
+
+		onPrestate(len(txs), work)
+
+		return nil
+	}
+
+	if err := execute(); err != nil || !rerun {
+		return err
+	}
+
+	shouldDelayFeeCal, rerun = false, false
+
+	return execute()
+}
@@ -0,0 +1,57 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// doubleSignEvidencePrefix + block number (uint64 big endian) + signer -> json encoded evidence
+var doubleSignEvidencePrefix = []byte("matic-bor-evidence-")
+
+func doubleSignEvidenceKey(number uint64, signer common.Address) []byte {
+	return append(append(doubleSignEvidencePrefix, encodeBlockNumber(number)...), signer.Bytes()...)
+}
+
+// HasDoubleSignEvidence checks whether evidence of the signer sealing two
+// headers at the given number is stored.
+func HasDoubleSignEvidence(db ethdb.KeyValueReader, number uint64, signer common.Address) bool {
+	has, _ := db.Has(doubleSignEvidenceKey(number, signer))
+	return has
+}
+
+// ReadDoubleSignEvidences retrieves the encoded evidences of the blocks in the
+// range [from, to], ordered by block number.
+func ReadDoubleSignEvidences(db ethdb.Iteratee, from uint64, to uint64) [][]byte {
+	it := db.NewIterator(doubleSignEvidencePrefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	evidences := make([][]byte, 0)
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(doubleSignEvidencePrefix)+8+common.AddressLength {
+			continue
+		}
+
+		if binary.BigEndian.Uint64(key[len(doubleSignEvidencePrefix):]) > to {
+			break
+		}
+
+		evidences = append(evidences, common.CopyBytes(it.Value()))
+	}
+
+	return evidences
+}
+
+// WriteDoubleSignEvidence stores the encoded evidence of the signer sealing two
+// headers at the given number.
+func WriteDoubleSignEvidence(db ethdb.KeyValueWriter, number uint64, signer common.Address, data []byte) error {
+	if err := db.Put(doubleSignEvidenceKey(number, signer), data); err != nil {
+		return fmt.Errorf("%w: %v for double sign evidence %d %s", ErrDBNotResponding, err, number, signer)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// validatorPerformancePrefix + validator address -> json encoded validator performance
+	validatorPerformancePrefix = []byte("matic-bor-liveness-")
+
+	// livenessHeadKey tracks the last block accounted for by the liveness tracker
+	livenessHeadKey = []byte("matic-bor-liveness-head")
+)
+
+func validatorPerformanceKey(address common.Address) []byte {
+	return append(validatorPerformancePrefix, address.Bytes()...)
+}
+
+// ReadValidatorPerformance retrieves the encoded performance of a validator.
+func ReadValidatorPerformance(db ethdb.KeyValueReader, address common.Address) []byte {
+	data, _ := db.Get(validatorPerformanceKey(address))
+	return data
+}
+
+// ReadAllValidatorPerformances retrieves the encoded performance of every
+// tracked validator.
+func ReadAllValidatorPerformances(db ethdb.Iteratee) map[common.Address][]byte {
+	it := db.NewIterator(validatorPerformancePrefix, nil)
+	defer it.Release()
+
+	performances := make(map[common.Address][]byte)
+
+	for it.Next() {
+		// The head key shares the prefix, only keys followed by an address are records
+		if len(it.Key()) != len(validatorPerformancePrefix)+common.AddressLength {
+			continue
+		}
+
+		address := common.BytesToAddress(it.Key()[len(validatorPerformancePrefix):])
+		performances[address] = common.CopyBytes(it.Value())
+	}
+
+	return performances
+}
+
+// WriteValidatorPerformance stores the encoded performance of a validator.
+func WriteValidatorPerformance(db ethdb.KeyValueWriter, address common.Address, data []byte) error {
+	if err := db.Put(validatorPerformanceKey(address), data); err != nil {
+		return fmt.Errorf("%w: %v for validator performance %s", ErrDBNotResponding, err, address)
+	}
+
+	return nil
+}
+
+// ReadLivenessHead retrieves the last block accounted for by the liveness
+// tracker, or nil if nothing has been tracked yet.
+func ReadLivenessHead(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(livenessHeadKey)
+	if len(data) != 8 {
+		if len(data) != 0 {
+			log.Error("Invalid liveness tracker head in database", "length", len(data))
+		}
+
+		return nil
+	}
+
+	number := binary.BigEndian.Uint64(data)
+
+	return &number
+}
+
+// WriteLivenessHead stores the last block accounted for by the liveness tracker.
+func WriteLivenessHead(db ethdb.KeyValueWriter, number uint64) error {
+	if err := db.Put(livenessHeadKey, encodeBlockNumber(number)); err != nil {
+		return fmt.Errorf("%w: %v for liveness head", ErrDBNotResponding, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// milestoneVotePrefix + end block number (uint64 big endian) + vote time (unix nanoseconds, uint64 big endian) -> json encoded vote
+var milestoneVotePrefix = []byte("matic-bor-milestone-vote-")
+
+func milestoneVoteKey(endBlock uint64, time uint64) []byte {
+	return append(append(milestoneVotePrefix, encodeBlockNumber(endBlock)...), encodeBlockNumber(time)...)
+}
+
+// ReadMilestoneVotes retrieves the encoded votes on the milestones ending in the
+// range [from, to], ordered by end block and time.
+func ReadMilestoneVotes(db ethdb.Iteratee, from uint64, to uint64) [][]byte {
+	it := db.NewIterator(milestoneVotePrefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	votes := make([][]byte, 0)
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(milestoneVotePrefix)+16 {
+			continue
+		}
+
+		if binary.BigEndian.Uint64(key[len(milestoneVotePrefix):]) > to {
+			break
+		}
+
+		votes = append(votes, common.CopyBytes(it.Value()))
+	}
+
+	return votes
+}
+
+// WriteMilestoneVote stores the encoded vote on the milestone ending at the
+// given block, cast at the given time.
+func WriteMilestoneVote(db ethdb.KeyValueWriter, endBlock uint64, time uint64, data []byte) error {
+	if err := db.Put(milestoneVoteKey(endBlock, time), data); err != nil {
+		return fmt.Errorf("%w: %v for milestone vote %d", ErrDBNotResponding, err, endBlock)
+	}
+
+	return nil
+}
+
+// DeleteMilestoneVotes deletes the votes on the milestones ending before the
+// given block.
+func DeleteMilestoneVotes(db ethdb.KeyValueStore, before uint64) error {
+	it := db.NewIterator(milestoneVotePrefix, nil)
+	defer it.Release()
+
+	batch := db.NewBatch()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(milestoneVotePrefix)+16 {
+			continue
+		}
+
+		if binary.BigEndian.Uint64(key[len(milestoneVotePrefix):]) >= before {
+			break
+		}
+
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("%w: %v for milestone votes before %d", ErrDBNotResponding, err, before)
+	}
+
+	return nil
+}
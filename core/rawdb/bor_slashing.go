@@ -0,0 +1,58 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// sealedHeaderPrefix + block number (uint64 big endian) + signer -> seal hash of the header sealed
+var sealedHeaderPrefix = []byte("matic-bor-sealed-")
+
+func sealedHeaderKey(number uint64, signer common.Address) []byte {
+	return append(append(sealedHeaderPrefix, encodeBlockNumber(number)...), signer.Bytes()...)
+}
+
+// ReadSealedHeader retrieves the seal hash of the header sealed by the signer
+// at the given number, or the zero hash if none was sealed.
+func ReadSealedHeader(db ethdb.KeyValueReader, number uint64, signer common.Address) common.Hash {
+	data, _ := db.Get(sealedHeaderKey(number, signer))
+	if len(data) != common.HashLength {
+		return common.Hash{}
+	}
+
+	return common.BytesToHash(data)
+}
+
+// IterateSealedHeaders calls fn for every sealed header recorded, ordered by
+// block number, until it returns false.
+func IterateSealedHeaders(db ethdb.Iteratee, fn func(number uint64, signer common.Address, sealHash common.Hash) bool) {
+	it := db.NewIterator(sealedHeaderPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(sealedHeaderPrefix)+8+common.AddressLength || len(it.Value()) != common.HashLength {
+			continue
+		}
+
+		number := binary.BigEndian.Uint64(key[len(sealedHeaderPrefix):])
+		signer := common.BytesToAddress(key[len(sealedHeaderPrefix)+8:])
+
+		if !fn(number, signer, common.BytesToHash(it.Value())) {
+			return
+		}
+	}
+}
+
+// WriteSealedHeader stores the seal hash of the header sealed by the signer at
+// the given number.
+func WriteSealedHeader(db ethdb.KeyValueWriter, number uint64, signer common.Address, sealHash common.Hash) error {
+	if err := db.Put(sealedHeaderKey(number, signer), sealHash.Bytes()); err != nil {
+		return fmt.Errorf("%w: %v for sealed header %d %s", ErrDBNotResponding, err, number, signer)
+	}
+
+	return nil
+}
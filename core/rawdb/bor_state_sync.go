@@ -0,0 +1,94 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// stateSyncPrefix + state sync id (uint64 big endian) + block hash -> rlp encoded state sync record
+	stateSyncPrefix = []byte("matic-bor-state-sync-id-")
+
+	// stateSyncL1TxPrefix + L1 tx hash + state sync id (uint64 big endian) -> nothing
+	stateSyncL1TxPrefix = []byte("matic-bor-state-sync-l1tx-")
+)
+
+func stateSyncKey(id uint64, hash common.Hash) []byte {
+	return append(append(stateSyncPrefix, encodeBlockNumber(id)...), hash.Bytes()...)
+}
+
+func stateSyncL1TxKey(txHash common.Hash, id uint64) []byte {
+	return append(append(stateSyncL1TxPrefix, txHash.Bytes()...), encodeBlockNumber(id)...)
+}
+
+// ReadStateSyncRecord retrieves the record of the state sync committed in the
+// canonical chain with the given id, nil if it isn't known. A state sync may be
+// committed in several blocks at the same height across reorgs, the records of
+// the non canonical ones are ignored.
+func ReadStateSyncRecord(db ethdb.Database, id uint64) *types.StateSyncRecord {
+	prefix := append(stateSyncPrefix, encodeBlockNumber(id)...)
+
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if len(it.Key()) != len(prefix)+common.HashLength {
+			continue
+		}
+
+		record := new(types.StateSyncRecord)
+		if err := rlp.DecodeBytes(it.Value(), record); err != nil {
+			log.Error("Invalid state sync record RLP", "id", id, "err", err)
+			continue
+		}
+
+		if ReadCanonicalHash(db, record.BlockNumber) == record.BlockHash {
+			return record
+		}
+	}
+
+	return nil
+}
+
+// ReadStateSyncIDsByL1TxHash retrieves the ids of the state syncs emitted by
+// the given L1 transaction, in ascending order.
+func ReadStateSyncIDsByL1TxHash(db ethdb.Iteratee, txHash common.Hash) []uint64 {
+	prefix := append(stateSyncL1TxPrefix, txHash.Bytes()...)
+
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	ids := make([]uint64, 0)
+
+	for it.Next() {
+		if len(it.Key()) != len(prefix)+8 {
+			continue
+		}
+
+		ids = append(ids, binary.BigEndian.Uint64(it.Key()[len(prefix):]))
+	}
+
+	return ids
+}
+
+// WriteStateSyncRecord stores the record of a state sync committed in a block,
+// and indexes it by the hash of the L1 transaction which emitted it.
+func WriteStateSyncRecord(db ethdb.KeyValueWriter, record *types.StateSyncRecord) {
+	data, err := rlp.EncodeToBytes(record)
+	if err != nil {
+		log.Crit("Failed to encode state sync record", "err", err)
+	}
+
+	if err := db.Put(stateSyncKey(record.ID, record.BlockHash), data); err != nil {
+		log.Crit("Failed to store state sync record", "err", err)
+	}
+
+	if err := db.Put(stateSyncL1TxKey(record.L1TxHash, record.ID), []byte{}); err != nil {
+		log.Crit("Failed to store state sync L1 tx lookup entry", "err", err)
+	}
+}
@@ -0,0 +1,121 @@
+package rawdb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// heimdallSpanPrefix + span id (uint64 big endian) -> json encoded heimdall span
+	heimdallSpanPrefix = []byte("matic-heimdall-span-")
+
+	// heimdallEventPrefix + state-sync id (uint64 big endian) -> json encoded event record
+	heimdallEventPrefix = []byte("matic-heimdall-event-")
+
+	// heimdallCheckpointPrefix + checkpoint number (uint64 big endian) -> json encoded checkpoint
+	heimdallCheckpointPrefix = []byte("matic-heimdall-checkpoint-")
+
+	// heimdallEventCoverageKey tracks the range of state-sync events fully cached on disk,
+	// it lies outside heimdallEventPrefix so that it can't collide with an event key
+	heimdallEventCoverageKey = []byte("matic-heimdall-coverage-event")
+)
+
+// HeimdallEventCoverage describes the state-sync events known to be complete in
+// the database: every event with an id in [FromID, NextID) is stored, and no
+// event with an id >= NextID has a record time before To.
+type HeimdallEventCoverage struct {
+	FromID uint64 `json:"fromID"`
+	NextID uint64 `json:"nextID"`
+	To     int64  `json:"to"`
+}
+
+func heimdallSpanKey(id uint64) []byte {
+	return append(heimdallSpanPrefix, encodeBlockNumber(id)...)
+}
+
+func heimdallEventKey(id uint64) []byte {
+	return append(heimdallEventPrefix, encodeBlockNumber(id)...)
+}
+
+func heimdallCheckpointKey(number uint64) []byte {
+	return append(heimdallCheckpointPrefix, encodeBlockNumber(number)...)
+}
+
+// ReadHeimdallSpan retrieves the encoded heimdall span with the given id.
+func ReadHeimdallSpan(db ethdb.KeyValueReader, id uint64) []byte {
+	data, _ := db.Get(heimdallSpanKey(id))
+	return data
+}
+
+// WriteHeimdallSpan stores the encoded heimdall span with the given id.
+func WriteHeimdallSpan(db ethdb.KeyValueWriter, id uint64, data []byte) error {
+	if err := db.Put(heimdallSpanKey(id), data); err != nil {
+		return fmt.Errorf("%w: %v for heimdall span %d", ErrDBNotResponding, err, id)
+	}
+
+	return nil
+}
+
+// ReadHeimdallEvent retrieves the encoded state-sync event record with the given id.
+func ReadHeimdallEvent(db ethdb.KeyValueReader, id uint64) []byte {
+	data, _ := db.Get(heimdallEventKey(id))
+	return data
+}
+
+// WriteHeimdallEvent stores the encoded state-sync event record with the given id.
+func WriteHeimdallEvent(db ethdb.KeyValueWriter, id uint64, data []byte) error {
+	if err := db.Put(heimdallEventKey(id), data); err != nil {
+		return fmt.Errorf("%w: %v for heimdall event %d", ErrDBNotResponding, err, id)
+	}
+
+	return nil
+}
+
+// ReadHeimdallCheckpoint retrieves the encoded checkpoint with the given number.
+func ReadHeimdallCheckpoint(db ethdb.KeyValueReader, number uint64) []byte {
+	data, _ := db.Get(heimdallCheckpointKey(number))
+	return data
+}
+
+// WriteHeimdallCheckpoint stores the encoded checkpoint with the given number.
+func WriteHeimdallCheckpoint(db ethdb.KeyValueWriter, number uint64, data []byte) error {
+	if err := db.Put(heimdallCheckpointKey(number), data); err != nil {
+		return fmt.Errorf("%w: %v for heimdall checkpoint %d", ErrDBNotResponding, err, number)
+	}
+
+	return nil
+}
+
+// ReadHeimdallEventCoverage retrieves the range of cached state-sync events, or
+// nil if nothing has been cached yet.
+func ReadHeimdallEventCoverage(db ethdb.KeyValueReader) *HeimdallEventCoverage {
+	data, _ := db.Get(heimdallEventCoverageKey)
+	if len(data) == 0 {
+		return nil
+	}
+
+	var coverage HeimdallEventCoverage
+	if err := json.Unmarshal(data, &coverage); err != nil {
+		log.Error("Invalid heimdall event coverage in database", "err", err)
+		return nil
+	}
+
+	return &coverage
+}
+
+// WriteHeimdallEventCoverage stores the range of cached state-sync events.
+func WriteHeimdallEventCoverage(db ethdb.KeyValueWriter, coverage *HeimdallEventCoverage) error {
+	enc, err := json.Marshal(coverage)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Put(heimdallEventCoverageKey, enc); err != nil {
+		return fmt.Errorf("%w: %v for heimdall event coverage", ErrDBNotResponding, err)
+	}
+
+	return nil
+}
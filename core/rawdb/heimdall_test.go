@@ -0,0 +1,26 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// Tests that no state-sync event overwrites the range of cached events, even
+// one whose id encodes to the suffix of the old coverage key.
+func TestHeimdallEventCoverage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	coverage := &HeimdallEventCoverage{FromID: 1, NextID: 10, To: 100}
+	if err := WriteHeimdallEventCoverage(db, coverage); err != nil {
+		t.Fatalf("failed to write coverage: %v", err)
+	}
+
+	id := binary.BigEndian.Uint64([]byte("coverage"))
+	if err := WriteHeimdallEvent(db, id, []byte("{}")); err != nil {
+		t.Fatalf("failed to write event %d: %v", id, err)
+	}
+
+	if have := ReadHeimdallEventCoverage(db); have == nil || *have != *coverage {
+		t.Fatalf("coverage mismatch: have %v, want %v", have, coverage)
+	}
+}
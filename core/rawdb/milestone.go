@@ -21,6 +21,7 @@ var (
 type Finality struct {
 	Block uint64
 	Hash  common.Hash
+	ID    string // Heimdall id, empty for the entries written before it was kept
 }
 
 type LockField struct {
@@ -35,9 +36,14 @@ type FutureMilestoneField struct {
 	List  map[uint64]common.Hash
 }
 
-func (f *Finality) set(block uint64, hash common.Hash) {
+func (f *Finality) set(block uint64, hash common.Hash, id string) {
 	f.Block = block
 	f.Hash = hash
+	f.ID = id
+}
+
+func (f *Finality) id() string {
+	return f.ID
 }
 
 type Milestone struct {
@@ -53,33 +59,53 @@ func (m *Milestone) block() (uint64, common.Hash) {
 }
 
 func ReadFinality[T BlockFinality[T]](db ethdb.KeyValueReader) (uint64, common.Hash, error) {
+	lastTV, err := readFinality[T](db)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+
+	block, hash := lastTV.block()
+
+	return block, hash, nil
+}
+
+// ReadFinalityID returns the heimdall id of the last whitelisted entry: the
+// milestone id, or the checkpoint number.
+func ReadFinalityID[T BlockFinality[T]](db ethdb.KeyValueReader) (string, error) {
+	lastTV, err := readFinality[T](db)
+	if err != nil {
+		return "", err
+	}
+
+	return lastTV.id(), nil
+}
+
+func readFinality[T BlockFinality[T]](db ethdb.KeyValueReader) (T, error) {
 	lastTV, key := getKey[T]()
 
 	data, err := db.Get(key)
 	if err != nil {
-		return 0, common.Hash{}, fmt.Errorf("%w: empty response for %s", err, string(key))
+		return lastTV, fmt.Errorf("%w: empty response for %s", err, string(key))
 	}
 
 	if len(data) == 0 {
-		return 0, common.Hash{}, fmt.Errorf("%w for %s", ErrEmptyLastFinality, string(key))
+		return lastTV, fmt.Errorf("%w for %s", ErrEmptyLastFinality, string(key))
 	}
 
 	if err = json.Unmarshal(data, lastTV); err != nil {
 		log.Error(fmt.Sprintf("Unable to unmarshal the last %s block number in database", string(key)), "err", err)
 
-		return 0, common.Hash{}, fmt.Errorf("%w(%v) for %s, data %v(%q)",
+		return lastTV, fmt.Errorf("%w(%v) for %s, data %v(%q)",
 			ErrIncorrectFinality, err, string(key), data, string(data))
 	}
 
-	block, hash := lastTV.block()
-
-	return block, hash, nil
+	return lastTV, nil
 }
 
-func WriteLastFinality[T BlockFinality[T]](db ethdb.KeyValueWriter, block uint64, hash common.Hash) error {
+func WriteLastFinality[T BlockFinality[T]](db ethdb.KeyValueWriter, block uint64, hash common.Hash, id string) error {
 	lastTV, key := getKey[T]()
 
-	lastTV.set(block, hash)
+	lastTV.set(block, hash, id)
 
 	enc, err := json.Marshal(lastTV)
 	if err != nil {
@@ -98,9 +124,10 @@ func WriteLastFinality[T BlockFinality[T]](db ethdb.KeyValueWriter, block uint64
 }
 
 type BlockFinality[T any] interface {
-	set(block uint64, hash common.Hash)
+	set(block uint64, hash common.Hash, id string)
 	clone() T
 	block() (uint64, common.Hash)
+	id() string
 }
 
 func getKey[T BlockFinality[T]]() (T, []byte) {
@@ -106,6 +106,9 @@ type StateDB struct {
 
 	preimages map[common.Hash][]byte
 
+	// State syncs committed by the consensus engine in the block
+	stateSyncs []*types.StateSyncData
+
 	// Per-transaction access list
 	accessList *accessList
 
@@ -531,6 +534,16 @@ func (s *StateDB) Preimages() map[common.Hash][]byte {
 	return s.preimages
 }
 
+// SetStateSyncs records the state syncs committed in the block.
+func (s *StateDB) SetStateSyncs(stateSyncs []*types.StateSyncData) {
+	s.stateSyncs = stateSyncs
+}
+
+// StateSyncs returns the state syncs committed in the block.
+func (s *StateDB) StateSyncs() []*types.StateSyncData {
+	return s.stateSyncs
+}
+
 // AddRefund adds gas to the refund counter
 func (s *StateDB) AddRefund(gas uint64) {
 	s.journal.append(refundChange{prev: s.refund})
@@ -1138,6 +1151,7 @@ func (s *StateDB) Copy() *StateDB {
 		logs:                 make(map[common.Hash][]*types.Log, len(s.logs)),
 		logSize:              s.logSize,
 		preimages:            make(map[common.Hash][]byte, len(s.preimages)),
+		stateSyncs:           s.stateSyncs,
 		journal:              newJournal(),
 		hasher:               crypto.NewKeccakState(),
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core/blockstm"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -66,7 +67,16 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		blockNumber = block.Number()
 		allLogs     []*types.Log
 		gp          = new(GasPool).AddGas(block.GasLimit())
+		declared    = block.GetTxDependency()
+		reads       [][]blockstm.ReadDescriptor
+		writes      [][]blockstm.WriteDescriptor
 	)
+	// Record the reads and writes of the transactions to verify the dependencies
+	// declared in the block, as done when executing it in parallel
+	if declared != nil && p.bc != nil {
+		reads = make([][]blockstm.ReadDescriptor, 0, len(block.Transactions()))
+		writes = make([][]blockstm.WriteDescriptor, 0, len(block.Transactions()))
+	}
 	// Mutate the block and state according to any hard-fork specs
 	if p.config.DAOForkSupport && p.config.DAOForkBlock != nil && p.config.DAOForkBlock.Cmp(block.Number()) == 0 {
 		misc.ApplyDAOHardFork(statedb)
@@ -91,14 +101,35 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 
 		statedb.SetTxContext(tx.Hash(), i)
 
+		if reads != nil {
+			statedb.AddEmptyMVHashMap()
+		}
+
 		receipt, err := applyTransaction(msg, p.config, gp, statedb, blockNumber, blockHash, tx, usedGas, vmenv, interruptCtx)
 		if err != nil {
 			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 
+		if reads != nil {
+			reads = append(reads, statedb.MVReadList())
+			writes = append(writes, statedb.MVFullWriteList())
+
+			statedb.ClearReadMap()
+			statedb.ClearWriteMap()
+		}
+
 		receipts = append(receipts, receipt)
 		allLogs = append(allLogs, receipt.Logs...)
 	}
+	if reads != nil {
+		txio := blockstm.MakeTxnInputOutput(len(reads))
+		txio.RecordReadAtOnce(reads)
+		txio.RecordAllWriteAtOnce(writes)
+
+		if err := p.bc.verifyTxDependency(block, declared, txio); err != nil {
+			return nil, nil, 0, err
+		}
+	}
 	// Fail if Shanghai not enabled and len(withdrawals) is non-zero.
 	withdrawals := block.Withdrawals()
 	if !p.config.IsShanghai(block.Number()) && withdrawals != nil {
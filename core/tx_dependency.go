@@ -0,0 +1,143 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/blockstm"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// ErrMissingTxDependency is returned when the transaction dependencies declared
+// by the producer of a block miss dependencies observed while executing it.
+var ErrMissingTxDependency = errors.New("declared transaction dependencies miss observed ones")
+
+var (
+	txDependencyVerifiedMeter = metrics.NewRegisteredMeter("chain/txdependency/verified", nil)
+	txDependencyMismatchMeter = metrics.NewRegisteredMeter("chain/txdependency/mismatch", nil)
+	txDependencyMissingMeter  = metrics.NewRegisteredMeter("chain/txdependency/missing", nil)
+)
+
+// txDependencyRecordedLimit is the number of recent blocks remembered, so that
+// a block verified by both the serial and the parallel processors, or replayed,
+// is only counted once.
+const txDependencyRecordedLimit = 1024
+
+// verifyTxDependency compares the transaction dependencies declared by the
+// producer of the block with the ones observed while executing it, by either
+// processor. Once the check is enabled by the fork, blocks whose declared
+// dependencies miss observed ones are rejected.
+//
+// The fees are only paid after the transactions when executing them in parallel,
+// so the balances they're paid to don't make dependencies.
+func (bc *BlockChain) verifyTxDependency(block *types.Block, declared [][]uint64, txio *blockstm.TxnInputOutput) error {
+	producer, _ := bc.engine.Author(block.Header())
+
+	ignored := []blockstm.Key{blockstm.NewSubpathKey(producer, state.BalancePath)}
+	if bc.chainConfig.Bor != nil && bc.chainConfig.IsLondon(block.Number()) {
+		burntContract := common.HexToAddress(bc.chainConfig.Bor.CalculateBurntContract(block.NumberU64()))
+		ignored = append(ignored, blockstm.NewSubpathKey(burntContract, state.BalancePath))
+	}
+
+	missing := blockstm.VerifyDependencies(declared, txio, ignored...)
+
+	bc.txDependencies.record(producer, block.NumberU64(), block.Hash(), missing)
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	log.Warn("Declared transaction dependencies miss observed ones", "number", block.Number(), "hash", block.Hash(), "producer", producer, "missing", len(missing))
+
+	if bc.chainConfig.Bor != nil && bc.chainConfig.Bor.IsTxDependencyCheck(block.Number()) {
+		return fmt.Errorf("%w: %d missing, transaction %d depends on %d", ErrMissingTxDependency, len(missing), missing[0].To, missing[0].From)
+	}
+
+	return nil
+}
+
+// TxDependencyStats counts the blocks of a producer whose declared transaction
+// dependencies were verified, and the ones missing observed dependencies.
+type TxDependencyStats struct {
+	Blocks       uint64                `json:"blocks"`
+	Mismatches   uint64                `json:"mismatches"`
+	MissingEdges uint64                `json:"missingEdges"`
+	LastMismatch *TxDependencyMismatch `json:"lastMismatch,omitempty"`
+}
+
+// TxDependencyMismatch describes a block whose declared transaction
+// dependencies miss observed ones.
+type TxDependencyMismatch struct {
+	Number  uint64                       `json:"number"`
+	Hash    common.Hash                  `json:"hash"`
+	Missing []blockstm.MissingDependency `json:"missing"`
+}
+
+// txDependencyTracker keeps the outcome of the verification of the transaction
+// dependencies of the imported blocks, per producer.
+type txDependencyTracker struct {
+	producers map[common.Address]*TxDependencyStats
+	recorded  lru.BasicLRU[common.Hash, struct{}]
+	lock      sync.RWMutex
+}
+
+func newTxDependencyTracker() *txDependencyTracker {
+	return &txDependencyTracker{
+		producers: make(map[common.Address]*TxDependencyStats),
+		recorded:  lru.NewBasicLRU[common.Hash, struct{}](txDependencyRecordedLimit),
+	}
+}
+
+func (t *txDependencyTracker) record(producer common.Address, number uint64, hash common.Hash, missing []blockstm.MissingDependency) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.recorded.Contains(hash) {
+		return
+	}
+
+	t.recorded.Add(hash, struct{}{})
+
+	txDependencyVerifiedMeter.Mark(1)
+
+	stats, ok := t.producers[producer]
+	if !ok {
+		stats = new(TxDependencyStats)
+		t.producers[producer] = stats
+	}
+
+	stats.Blocks++
+
+	if len(missing) == 0 {
+		return
+	}
+
+	txDependencyMismatchMeter.Mark(1)
+	txDependencyMissingMeter.Mark(int64(len(missing)))
+
+	stats.Mismatches++
+	stats.MissingEdges += uint64(len(missing))
+	stats.LastMismatch = &TxDependencyMismatch{
+		Number:  number,
+		Hash:    hash,
+		Missing: missing,
+	}
+}
+
+func (t *txDependencyTracker) stats() map[common.Address]TxDependencyStats {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	stats := make(map[common.Address]TxDependencyStats, len(t.producers))
+	for producer, s := range t.producers {
+		stats[producer] = *s
+	}
+
+	return stats
+}
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that the declared transaction dependencies are verified the same way
+// whichever processor executes the block, and that blocks missing observed ones
+// are only rejected from the fork on.
+func TestTxDependencyCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		serial, parallel bool
+		speculativeProcs int
+	}{
+		{"serial", true, false, 0},
+		{"parallel", false, true, 8},
+		{"both", true, true, 8},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				db      = rawdb.NewMemoryDatabase()
+				key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+				key2, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+				addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+				addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+				counter = common.HexToAddress("0xc0ffee")
+				config  = *params.TestChainConfig
+			)
+
+			config.Bor = &params.BorConfig{
+				BurntContract:          map[string]string{"0": "0x000000000000000000000000000000000000dead"},
+				TxDependencyCheckBlock: big.NewInt(2),
+			}
+
+			gspec := &Genesis{
+				Config: &config,
+				Alloc: GenesisAlloc{
+					addr1: {Balance: big.NewInt(params.Ether)},
+					addr2: {Balance: big.NewInt(params.Ether)},
+					// SLOAD(0), ADD 1, SSTORE(0)
+					counter: {Code: common.FromHex("0x60005460010160005500"), Balance: common.Big0},
+				},
+			}
+			signer := types.LatestSigner(gspec.Config)
+
+			// Both transactions increment the counter, the second one depends on the
+			// first one. It is only declared in the second block.
+			declared := [][][]uint64{{{}, {}}, {{}, {0}}, {{}, {}}}
+
+			_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), len(declared), func(i int, gen *BlockGen) {
+				extra := make([]byte, types.ExtraVanityLength)
+				data, _ := rlp.EncodeToBytes(types.BlockExtraData{TxDependency: declared[i]})
+				extra = append(extra, data...)
+				extra = append(extra, make([]byte, types.ExtraSealLength)...)
+
+				gen.SetExtra(extra)
+
+				tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr1), counter, common.Big0, 100000, gen.BaseFee(), nil), signer, key1)
+				gen.AddTx(tx)
+
+				tx, _ = types.SignTx(types.NewTransaction(gen.TxNonce(addr2), counter, common.Big0, 100000, gen.BaseFee(), nil), signer, key2)
+				gen.AddTx(tx)
+			})
+
+			chain, err := NewBlockChain(db, nil, gspec, nil, ethash.NewFullFaker(), vm.Config{ParallelEnable: tt.parallel, ParallelSpeculativeProcesses: tt.speculativeProcs}, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("failed to create chain: %v", err)
+			}
+			defer chain.Stop()
+
+			if tt.parallel {
+				chain.parallelProcessor = NewParallelStateProcessor(chain.chainConfig, chain, chain.engine)
+			}
+
+			if !tt.serial {
+				chain.processor = nil
+			}
+
+			// Before the fork, the mismatch is only recorded
+			if _, err := chain.InsertChain(blocks[:1]); err != nil {
+				t.Fatalf("failed to insert block before the fork: %v", err)
+			}
+
+			stats := chain.TxDependencyStats()[common.Address{}]
+			if stats.Blocks != 1 || stats.Mismatches != 1 || stats.MissingEdges != 1 {
+				t.Fatalf("wrong verification of the dependencies: %+v", stats)
+			}
+
+			if missing := stats.LastMismatch.Missing; len(missing) != 1 || missing[0].From != 0 || missing[0].To != 1 {
+				t.Fatalf("wrong missing dependencies: %v", missing)
+			}
+
+			// From the fork on, the blocks with the right dependencies are accepted,
+			// the other ones are rejected
+			if _, err := chain.InsertChain(blocks[1:2]); err != nil {
+				t.Fatalf("failed to insert block with the right dependencies: %v", err)
+			}
+
+			if _, err := chain.InsertChain(blocks[2:]); !errors.Is(err, ErrMissingTxDependency) {
+				t.Fatalf("wrong error after the fork: have %v, want %v", err, ErrMissingTxDependency)
+			}
+
+			stats = chain.TxDependencyStats()[common.Address{}]
+			if stats.Blocks != 3 || stats.Mismatches != 2 {
+				t.Fatalf("wrong verification of the dependencies: %+v", stats)
+			}
+		})
+	}
+}
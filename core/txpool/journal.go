@@ -17,7 +17,9 @@
 package txpool
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"io/fs"
 	"os"
@@ -32,6 +34,73 @@ import (
 // into the journal, but no such file is currently open.
 var errNoActiveJournal = errors.New("no active journal")
 
+// journalEntryVersion is the version of the encoding of the journaled conditional
+// transactions.
+const journalEntryVersion = 1
+
+// journalEntry is the versioned encoding of a journaled transaction carrying the
+// options of a conditional transaction (EIP-4337), which aren't part of the
+// transaction encoding. The transactions without options are journaled as is,
+// keeping the journals written by previous versions readable.
+type journalEntry struct {
+	Version uint
+	Tx      *types.Transaction
+	Options []byte // JSON encoded options
+}
+
+// encodeJournalEntry writes the transaction to the journal, in a versioned
+// entry if it is a conditional transaction.
+func encodeJournalEntry(w io.Writer, tx *types.Transaction) error {
+	options := tx.GetOptions()
+	if options == nil {
+		return rlp.Encode(w, tx)
+	}
+
+	enc, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	return rlp.Encode(w, &journalEntry{Version: journalEntryVersion, Tx: tx, Options: enc})
+}
+
+// decodeJournalEntry parses a journaled transaction, either a plain transaction
+// or a versioned entry. A legacy transaction is an RLP list as well, but with
+// more fields than an entry.
+func decodeJournalEntry(raw []byte) (*types.Transaction, error) {
+	kind, content, _, err := rlp.Split(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if count, err := rlp.CountValues(content); kind != rlp.List || err != nil || count != 3 {
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(raw, tx); err != nil {
+			return nil, err
+		}
+
+		return tx, nil
+	}
+
+	entry := new(journalEntry)
+	if err := rlp.DecodeBytes(raw, entry); err != nil {
+		return nil, err
+	}
+
+	if entry.Version != journalEntryVersion {
+		return nil, fmt.Errorf("unsupported journal entry version %d", entry.Version)
+	}
+
+	options := new(types.OptionsAA4337)
+	if err := json.Unmarshal(entry.Options, options); err != nil {
+		return nil, err
+	}
+
+	entry.Tx.PutOptions(options)
+
+	return entry.Tx, nil
+}
+
 // devNull is a WriteCloser that just discards anything written into it. Its
 // goal is to allow the transaction journal to write into a fake journal when
 // loading transactions on startup without printing warnings due to no file
@@ -99,8 +168,8 @@ func (journal *journal) load(add func([]*types.Transaction) []error) error {
 
 	for {
 		// Parse the next transaction and terminate on error
-		tx := new(types.Transaction)
-		if err = stream.Decode(tx); err != nil {
+		raw, err := stream.Raw()
+		if err != nil {
 			if err != io.EOF {
 				failure = err
 			}
@@ -111,6 +180,16 @@ func (journal *journal) load(add func([]*types.Transaction) []error) error {
 
 			break
 		}
+
+		tx, err := decodeJournalEntry(raw)
+		if err != nil {
+			log.Debug("Failed to decode journaled transaction", "err", err)
+
+			total++
+			dropped++
+
+			continue
+		}
 		// New transaction parsed, queue up for later, import if threshold is reached
 		total++
 
@@ -130,7 +209,7 @@ func (journal *journal) insert(tx *types.Transaction) error {
 		return errNoActiveJournal
 	}
 
-	if err := rlp.Encode(journal.writer, tx); err != nil {
+	if err := encodeJournalEntry(journal.writer, tx); err != nil {
 		return err
 	}
 
@@ -158,7 +237,7 @@ func (journal *journal) rotate(all map[common.Address]types.Transactions) error
 
 	for _, txs := range all {
 		for _, tx := range txs {
-			if err = rlp.Encode(replacement, tx); err != nil {
+			if err = encodeJournalEntry(replacement, tx); err != nil {
 				replacement.Close()
 				return err
 			}
@@ -0,0 +1,83 @@
+package txpool
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that the options of the conditional transactions survive a journal
+// round trip, and that journals of plain transactions are still readable.
+func TestJournalConditionalTransactions(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+
+	var (
+		path = filepath.Join(t.TempDir(), "transactions.rlp")
+
+		legacy  = transaction(0, 100000, key)
+		dynamic = dynamicFeeTx(1, 100000, big.NewInt(2), big.NewInt(1), key)
+		plain   = transaction(2, 100000, key)
+
+		timestampMax = uint64(1000)
+		options      = &types.OptionsAA4337{
+			KnownAccounts:  types.KnownAccounts{},
+			BlockNumberMin: big.NewInt(10),
+			BlockNumberMax: big.NewInt(20),
+			TimestampMax:   &timestampMax,
+		}
+	)
+
+	types.InsertKnownAccounts(options.KnownAccounts, common.HexToAddress("0x1"), common.HexToHash("0x2"))
+	types.InsertKnownAccounts(options.KnownAccounts, common.HexToAddress("0x3"), map[common.Hash]common.Hash{common.HexToHash("0x4"): common.HexToHash("0x5")})
+
+	legacy.PutOptions(options)
+	dynamic.PutOptions(options)
+
+	// A journal written by a previous version holds plain transactions only
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, rlp.Encode(file, plain))
+	require.NoError(t, file.Close())
+
+	journal := newTxJournal(path)
+
+	var loaded []*types.Transaction
+
+	load := func(txs []*types.Transaction) []error {
+		loaded = append(loaded, txs...)
+		return make([]error, len(txs))
+	}
+
+	require.NoError(t, journal.load(load))
+	require.Len(t, loaded, 1)
+	require.Equal(t, plain.Hash(), loaded[0].Hash())
+	require.Nil(t, loaded[0].GetOptions())
+
+	// The conditional transactions are journaled along the plain ones
+	require.NoError(t, journal.rotate(map[common.Address]types.Transactions{{}: {loaded[0], legacy}}))
+	require.NoError(t, journal.insert(dynamic))
+	require.NoError(t, journal.close())
+
+	loaded = nil
+
+	require.NoError(t, journal.load(load))
+	require.Len(t, loaded, 3)
+
+	require.Equal(t, plain.Hash(), loaded[0].Hash())
+	require.Nil(t, loaded[0].GetOptions())
+
+	for i, tx := range []*types.Transaction{legacy, dynamic} {
+		require.Equal(t, tx.Hash(), loaded[i+1].Hash())
+		require.Equal(t, options, loaded[i+1].GetOptions())
+	}
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Sources of the blocks the 'finalized' and 'safe' block tags resolve to on bor.
+const (
+	FinalityMilestone     = "milestone"
+	FinalityCheckpoint    = "checkpoint"
+	FinalityConfirmations = "confirmations"
+)
+
+// FinalityBlock is a block the 'finalized' or 'safe' block tag resolves to, with
+// the heimdall id of the milestone or checkpoint it is resolved from, if known.
+type FinalityBlock struct {
+	Number           uint64      `json:"number"`
+	Hash             common.Hash `json:"hash"`
+	Source           string      `json:"source"`
+	MilestoneID      string      `json:"milestoneId,omitempty"`
+	CheckpointNumber uint64      `json:"checkpointNumber,omitempty"`
+}
+
+// Finality is the finalized and the safe block of the chain, nil if unknown.
+type Finality struct {
+	Finalized *FinalityBlock `json:"finalized"`
+	Safe      *FinalityBlock `json:"safe"`
+	Recovery  *ChainRecovery `json:"recovery,omitempty"`
+}
+
+// FinalizedHead is the header of the end block of a milestone or a checkpoint
+// whitelisted from heimdall, which advanced the finality of the chain.
+type FinalizedHead struct {
+	Header *Header `json:"header"`
+	*FinalityBlock
+}
+
+// Statuses of the recovery of the chain.
+const (
+	ChainRecoveryRunning   = "running"
+	ChainRecoverySucceeded = "succeeded"
+	ChainRecoveryFailed    = "failed"
+)
+
+// ChainRecovery is the status of the last recovery of the chain, started after
+// its blocks mismatched a milestone or a checkpoint of heimdall. The hash is the
+// end block hash of a milestone and the root hash of a checkpoint.
+type ChainRecovery struct {
+	Status       string     `json:"status"`
+	Source       string     `json:"source"`
+	StartBlock   uint64     `json:"startBlock"`
+	EndBlock     uint64     `json:"endBlock"`
+	Hash         string     `json:"hash"`
+	Started      time.Time  `json:"started"`
+	Finished     *time.Time `json:"finished,omitempty"`
+	Attempts     int        `json:"attempts"`
+	ValidPeers   int        `json:"validPeers"`
+	DroppedPeers int        `json:"droppedPeers"`
+	Error        string     `json:"error,omitempty"`
+}
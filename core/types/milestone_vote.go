@@ -0,0 +1,15 @@
+package types
+
+import "time"
+
+// MilestoneVote is the answer of the node to a request of heimdall to vote on a
+// milestone. The reason is set if the node didn't vote for the milestone.
+type MilestoneVote struct {
+	Time        time.Time `json:"time"`
+	StartBlock  uint64    `json:"startBlock"`
+	EndBlock    uint64    `json:"endBlock"`
+	Hash        string    `json:"hash"`
+	MilestoneID string    `json:"milestoneId"`
+	Vote        bool      `json:"vote"`
+	Reason      string    `json:"reason,omitempty"`
+}
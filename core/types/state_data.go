@@ -8,4 +8,19 @@ type StateSyncData struct {
 	Contract common.Address
 	Data     string
 	TxHash   common.Hash
+	LogIndex uint // Index in the block of the first log emitted while committing the state
+}
+
+// StateSyncRecord locates a state sync committed in a block. The logs emitted
+// while committing the state are part of the bor receipt of the block, starting
+// at LogIndex.
+type StateSyncRecord struct {
+	ID          uint64         `json:"id"`
+	Contract    common.Address `json:"contract"`
+	Data        string         `json:"data"`
+	L1TxHash    common.Hash    `json:"l1TxHash"`
+	BlockNumber uint64         `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	BorTxHash   common.Hash    `json:"borTxHash"`
+	LogIndex    uint           `json:"logIndex"`
 }
@@ -56,6 +56,7 @@ type Config struct {
 	// parallel EVM configs
 	ParallelEnable               bool
 	ParallelSpeculativeProcesses int
+	ParallelAdaptiveScheduling   bool // Schedules the transactions from the conflicts of the recent blocks
 }
 
 // ScopeContext contains the things that are per-call, such as stack and memory,
@@ -30,7 +30,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/blockstm"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -287,20 +289,11 @@ func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
 	var header *types.Header
 	if blockNr == rpc.LatestBlockNumber {
 		header = api.eth.blockchain.CurrentBlock()
-	} else if blockNr == rpc.FinalizedBlockNumber {
-		finalBlockNumber, err := getFinalizedBlockNumber(api.eth)
-		if err != nil {
-			return state.Dump{}, fmt.Errorf("finalized block not found")
+	} else if blockNr == rpc.FinalizedBlockNumber || blockNr == rpc.SafeBlockNumber {
+		var err error
+		if header, err = finalityHeader(api.eth, blockNr); err != nil {
+			return state.Dump{}, err
 		}
-
-		block := api.eth.blockchain.CurrentFinalizedBlock(finalBlockNumber)
-		if block == nil {
-			return state.Dump{}, fmt.Errorf("block #%d not found", blockNr)
-		}
-
-		header = block.Header()
-	} else if blockNr == rpc.SafeBlockNumber {
-		header = api.eth.blockchain.CurrentSafeBlock()
 	} else {
 		block := api.eth.blockchain.GetBlockByNumber(uint64(blockNr))
 		if block == nil {
@@ -395,20 +388,10 @@ func (api *DebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, start hex
 			var header *types.Header
 			if number == rpc.LatestBlockNumber {
 				header = api.eth.blockchain.CurrentBlock()
-			} else if number == rpc.FinalizedBlockNumber {
-				finalBlockNumber, err := getFinalizedBlockNumber(api.eth)
-				if err != nil {
-					return state.IteratorDump{}, fmt.Errorf("finalized block not found")
+			} else if number == rpc.FinalizedBlockNumber || number == rpc.SafeBlockNumber {
+				if header, err = finalityHeader(api.eth, number); err != nil {
+					return state.IteratorDump{}, err
 				}
-
-				block := api.eth.blockchain.CurrentFinalizedBlock(finalBlockNumber)
-				if block == nil {
-					return state.IteratorDump{}, fmt.Errorf("finalized block not found")
-				}
-
-				header = block.Header()
-			} else if number == rpc.SafeBlockNumber {
-				header = api.eth.blockchain.CurrentSafeBlock()
 			} else {
 				block := api.eth.blockchain.GetBlockByNumber(uint64(number))
 				if block == nil {
@@ -706,26 +689,99 @@ func (api *DebugAPI) SetTrieFlushInterval(interval string) error {
 	return nil
 }
 
-func getFinalizedBlockNumber(eth *Ethereum) (uint64, error) {
-	currentBlockNum := eth.BlockChain().CurrentBlock()
+const (
+	// parallelReportHotKeys is the number of keys causing the most dependencies
+	// between transactions returned by ParallelExecutionReport.
+	parallelReportHotKeys = 20
 
-	doExist, number, hash := eth.Downloader().GetWhitelistedMilestone()
-	if doExist && number <= currentBlockNum.Number.Uint64() {
-		block := eth.BlockChain().GetBlockByNumber(number)
+	// parallelReportReexec is the number of blocks ParallelExecutionReport is
+	// willing to re-execute to produce the missing state of the parent block.
+	parallelReportReexec = uint64(128)
 
-		if block.Hash() == hash {
-			return number, nil
-		}
+	// stateSyncSimulationReexec is the number of blocks SimulateStateSync is
+	// willing to re-execute to produce the missing state of the parent block.
+	stateSyncSimulationReexec = uint64(128)
+)
+
+// ParallelExecutionReport re-executes a block with the parallel state processor
+// and reports the dependencies between its transactions, how often they were
+// re-executed, and the keys causing the most conflicts.
+func (api *DebugAPI) ParallelExecutionReport(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*blockstm.ExecutionReport, error) {
+	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
 	}
 
-	doExist, number, hash = eth.Downloader().GetWhitelistedCheckpoint()
-	if doExist && number <= currentBlockNum.Number.Uint64() {
-		block := eth.BlockChain().GetBlockByNumber(number)
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
 
-		if block.Hash() == hash {
-			return number, nil
-		}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not executed")
+	}
+
+	parent := api.eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+
+	statedb, release, err := api.eth.StateAtBlock(ctx, parent, parallelReportReexec, nil, true, false)
+	if err != nil {
+		return nil, err
 	}
+	defer release()
+
+	cfg := *api.eth.blockchain.GetVMConfig()
+	if cfg.ParallelSpeculativeProcesses == 0 {
+		cfg.ParallelSpeculativeProcesses = runtime.NumCPU()
+	}
+
+	processor := core.NewParallelStateProcessor(api.eth.blockchain.Config(), api.eth.blockchain, api.eth.engine)
+
+	return processor.ExecutionReport(block, statedb, cfg, parallelReportHotKeys)
+}
+
+// TxDependencyStats returns, per block producer, how many imported blocks had
+// their declared transaction dependencies verified, and how many of them miss
+// dependencies observed while executing the block.
+func (api *DebugAPI) TxDependencyStats() (map[common.Address]core.TxDependencyStats, error) {
+	return api.eth.blockchain.TxDependencyStats(), nil
+}
+
+// SimulateStateSync replays the commit of the state syncs of a block starting a
+// sprint on top of the state of its parent, without writing anything. The events
+// are fetched from Heimdall again, and the outcome of each of them is returned:
+// whether it would be committed, the gas it uses and the logs it emits, or why
+// it would be rejected.
+func (api *DebugAPI) SimulateStateSync(ctx context.Context, number rpc.BlockNumber) (*bor.StateSyncSimulation, error) {
+	engine, ok := api.eth.engine.(*bor.Bor)
+	if !ok {
+		return nil, errors.New("state syncs are only committed by the bor consensus engine")
+	}
+
+	block, err := api.eth.APIBackend.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+
+	if block.NumberU64() == 0 {
+		return nil, errors.New("no state sync is committed in genesis")
+	}
+
+	parent := api.eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+
+	statedb, release, err := api.eth.StateAtBlock(ctx, parent, stateSyncSimulationReexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
-	return 0, fmt.Errorf("No finalized block")
+	return engine.SimulateStateSync(ctx, statedb, block.Header(), api.eth.blockchain)
 }
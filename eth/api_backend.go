@@ -78,33 +78,8 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 		return b.eth.blockchain.CurrentBlock(), nil
 	}
 
-	if number == rpc.FinalizedBlockNumber {
-		finalBlockNumber, err := getFinalizedBlockNumber(b.eth)
-		if err != nil {
-			return nil, errors.New("finalized block not found")
-		}
-
-		block := b.eth.blockchain.CurrentFinalizedBlock(finalBlockNumber)
-
-		if block != nil {
-			return block.Header(), nil
-		}
-
-		return nil, errors.New("finalized block not found")
-	}
-
-	if number == rpc.SafeBlockNumber {
-		if !b.eth.Merger().TDDReached() {
-			return nil, errors.New("'safe' tag not supported on pre-merge network")
-		}
-
-		block := b.eth.blockchain.CurrentSafeBlock()
-
-		if block != nil {
-			return block, nil
-		}
-
-		return nil, errors.New("safe block not found")
+	if number == rpc.FinalizedBlockNumber || number == rpc.SafeBlockNumber {
+		return finalityHeader(b.eth, number)
 	}
 
 	return b.eth.blockchain.GetHeaderByNumber(uint64(number)), nil
@@ -150,22 +125,12 @@ func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumbe
 		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
 	}
 
-	if number == rpc.FinalizedBlockNumber {
-		finalBlocknumber, err := getFinalizedBlockNumber(b.eth)
+	if number == rpc.FinalizedBlockNumber || number == rpc.SafeBlockNumber {
+		header, err := finalityHeader(b.eth, number)
 		if err != nil {
-			return nil, errors.New("finalized block not found")
+			return nil, err
 		}
 
-		return b.eth.blockchain.CurrentFinalizedBlock(finalBlocknumber), nil
-	}
-
-	if number == rpc.SafeBlockNumber {
-		if !b.eth.Merger().TDDReached() {
-			return nil, errors.New("'safe' tag not supported on pre-merge network")
-		}
-
-		header := b.eth.blockchain.CurrentSafeBlock()
-
 		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
 	}
 
@@ -322,7 +287,9 @@ func (b *EthAPIBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscri
 }
 
 func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
-	if signedTx.GetOptions() != nil && !b.eth.Miner().GetWorker().IsRunning() {
+	// Conditional transactions aren't propagated over `eth`, they are either mined
+	// locally or relayed to the trusted `condtx` peers
+	if signedTx.GetOptions() != nil && !b.eth.Miner().GetWorker().IsRunning() && b.eth.handler.condTxPeers.len() == 0 {
 		return errors.New("bundled transactions are not broadcasted therefore they will not submitted to the transaction pool")
 	}
 
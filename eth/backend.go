@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallsim"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
@@ -47,6 +48,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/protocols/condtx"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -108,6 +110,8 @@ type Ethereum struct {
 
 	closeCh chan struct{} // Channel to signal the background processes to exit
 
+	chainRecovery *chainRecovery // Recovers the chain after a mismatch with heimdall
+
 	shutdownTracker *shutdowncheck.ShutdownTracker // Tracks if and when the node has shutdown ungracefully
 }
 
@@ -176,6 +180,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		bloomIndexer:      core.NewBloomIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms),
 		p2pServer:         stack.Server(),
 		closeCh:           make(chan struct{}),
+		chainRecovery:     newChainRecovery(),
 		shutdownTracker:   shutdowncheck.NewShutdownTracker(chainDb),
 	}
 
@@ -233,6 +238,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			EnablePreimageRecording:      config.EnablePreimageRecording,
 			ParallelEnable:               config.ParallelEVM.Enable,
 			ParallelSpeculativeProcesses: config.ParallelEVM.SpeculativeProcesses,
+			ParallelAdaptiveScheduling:   config.ParallelEVM.AdaptiveScheduling,
 		}
 		cacheConfig = &core.CacheConfig{
 			TrieCleanLimit:      config.TrieCleanCache,
@@ -264,6 +270,17 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, err
 	}
 
+	if borEngine, ok := ethereum.engine.(*bor.Bor); ok {
+		// The heimdall simulator derives checkpoints and milestones from the local chain
+		if simulator, ok := borEngine.HeimdallClient.(*heimdallsim.Simulator); ok {
+			simulator.SetChain(ethereum.blockchain)
+		}
+
+		if config.BorLiveness {
+			borEngine.StartLivenessTracker(ethereum.blockchain)
+		}
+	}
+
 	_ = ethereum.engine.VerifyHeader(ethereum.blockchain, ethereum.blockchain.CurrentHeader(), true) // TODO think on it
 
 	// BOR changes
@@ -400,6 +417,9 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "debug",
 			Service:   NewDebugAPI(s),
+		}, {
+			Namespace: "bor",
+			Service:   NewBorAPI(s),
 		}, {
 			Namespace: "net",
 			Service:   s.netRPCService,
@@ -622,6 +642,10 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler), s.snapDialCandidates)...)
 	}
 
+	if s.config.ConditionalTxRelay {
+		protos = append(protos, condtx.MakeProtocols((*condTxHandler)(s.handler))...)
+	}
+
 	return protos
 }
 
@@ -647,9 +671,21 @@ func (s *Ethereum) Start() error {
 		maxPeers -= s.config.LightPeers
 	}
 
+	// A new node bootstrapped from a checkpoint doesn't sync from genesis
+	bootstrap := s.config.HeimdallBootstrap && s.blockchain.CurrentBlock().Number.Uint64() == 0
+	if bootstrap {
+		atomic.StoreUint32(&s.handler.bootstrapping, 1)
+	} else if s.config.HeimdallBootstrap {
+		log.Info("Chain not empty, skipping the checkpoint bootstrap")
+	}
+
 	// Start the networking layer and the light server if requested
 	s.handler.Start(maxPeers)
 
+	if bootstrap {
+		go s.startCheckpointBootstrap()
+	}
+
 	go s.startCheckpointWhitelistService()
 	go s.startMilestoneWhitelistService()
 	go s.startNoAckMilestoneService()
@@ -769,7 +805,15 @@ func (s *Ethereum) handleWhitelistCheckpoint(ctx context.Context, ethHandler *et
 		return err
 	}
 
-	ethHandler.downloader.ProcessCheckpoint(blockNum, blockHash)
+	// The heimdall number of the checkpoint is only fetched once it changes
+	doExist, number, hash := ethHandler.downloader.GetWhitelistedCheckpoint()
+
+	checkpointNumber := ethHandler.downloader.GetWhitelistedCheckpointNumber()
+	if !doExist || number != blockNum || hash != blockHash || checkpointNumber == 0 {
+		checkpointNumber = ethHandler.fetchCheckpointNumber(ctx, bor, blockNum)
+	}
+
+	ethHandler.downloader.ProcessCheckpoint(blockNum, blockHash, checkpointNumber)
 
 	return nil
 }
@@ -780,7 +824,7 @@ type heimdallHandler func(ctx context.Context, ethHandler *ethHandler, bor *bor.
 func (s *Ethereum) handleMilestone(ctx context.Context, ethHandler *ethHandler, bor *bor.Bor) error {
 	// Create a new bor verifier, which will be used to verify checkpoints and milestones
 	verifier := newBorVerifier()
-	num, hash, err := ethHandler.fetchWhitelistMilestone(ctx, bor, s, verifier)
+	num, hash, milestoneID, err := ethHandler.fetchWhitelistMilestone(ctx, bor, s, verifier)
 
 	// If the current chain head is behind the received milestone, add it to the future milestone
 	// list. Also, the hash mismatch (end block hash) error will lead to rewind so also
@@ -797,7 +841,7 @@ func (s *Ethereum) handleMilestone(ctx context.Context, ethHandler *ethHandler,
 		return err
 	}
 
-	ethHandler.downloader.ProcessMilestone(num, hash)
+	ethHandler.downloader.ProcessMilestone(num, hash, milestoneID)
 
 	return nil
 }
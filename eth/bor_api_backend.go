@@ -39,8 +39,18 @@ func (b *EthAPIBackend) GetRootHash(ctx context.Context, starBlockNr uint64, end
 	return root, nil
 }
 
-// GetRootHash returns root hash for given start and end block
+// GetVoteOnHash votes on the milestone of the given range and hash proposed by
+// heimdall. The outcome is written to the milestone votes audit log.
 func (b *EthAPIBackend) GetVoteOnHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64, hash string, milestoneId string) (bool, error) {
+	vote, err := b.voteOnHash(ctx, starBlockNr, endBlockNr, hash, milestoneId)
+	if !errors.Is(err, errBorEngineNotAvailable) {
+		writeMilestoneVote(b.eth.ChainDb(), b.eth.BlockChain().CurrentBlock().Number.Uint64(), starBlockNr, endBlockNr, hash, milestoneId, vote, err)
+	}
+
+	return vote, err
+}
+
+func (b *EthAPIBackend) voteOnHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64, hash string, milestoneId string) (bool, error) {
 	var api *bor.API
 
 	for _, _api := range b.eth.Engine().APIs(b.eth.BlockChain()) {
@@ -0,0 +1,330 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	bootstrapRetryDelay   = 10 * time.Second // Delay between the attempts to fetch the blocks of the checkpoint
+	bootstrapPollInterval = 5 * time.Second  // Interval between the checks of the progress of the backfill
+)
+
+var (
+	// errBootstrapAnchor is returned when no peer serves the blocks of the
+	// checkpoint the chain is bootstrapped from.
+	errBootstrapAnchor = errors.New("no peer served the blocks of the checkpoint")
+
+	// errBootstrapMismatch is returned when the blocks backfilled before the
+	// anchor of the bootstrap mismatch a checkpoint preceding it.
+	errBootstrapMismatch = errors.New("backfilled blocks mismatch checkpoint")
+
+	bootstrapVerifiedMeter = metrics.NewRegisteredMeter("chain/bootstrap/verified", nil)
+	bootstrapMismatchMeter = metrics.NewRegisteredMeter("chain/bootstrap/mismatch", nil)
+	bootstrapHaltedGauge   = metrics.NewRegisteredGauge("chain/bootstrap/halted", nil)
+)
+
+// startCheckpointBootstrap syncs a new node from the latest checkpoint of
+// heimdall rather than from genesis. The end block of the checkpoint, whose
+// blocks are fetched from the peers and checked against its root hash, is the
+// trusted anchor of a beacon sync: the headers and bodies are backfilled in
+// reverse from it and the state is snap synced. The legacy sync is disabled
+// until the local chain reaches the anchor and the backfilled blocks are
+// verified against the configured number of preceding checkpoints, it then
+// syncs the following blocks while the older checkpoints are verified.
+func (s *Ethereum) startCheckpointBootstrap() {
+	ethHandler, bor, err := s.getHandler()
+	if err != nil {
+		log.Error("Unable to bootstrap the chain from a checkpoint", "err", err)
+		atomic.StoreUint32(&s.handler.bootstrapping, 0)
+
+		return
+	}
+
+	s.runCheckpointBootstrap(ethHandler, bor)
+}
+
+// runCheckpointBootstrap bootstraps the chain from the latest checkpoint. Once
+// the legacy sync is enabled, the checkpoints older than the ones verified
+// during the bootstrap are verified in the background. If the backfilled blocks
+// mismatch a checkpoint, the bootstrap is halted rather than syncing on top of
+// these blocks.
+func (s *Ethereum) runCheckpointBootstrap(h *ethHandler, bor *bor.Bor) {
+	number, err := s.bootstrapFromCheckpoint(h, bor)
+	if errors.Is(err, errBootstrapMismatch) {
+		s.haltCheckpointBootstrap(h, err)
+		return
+	}
+
+	atomic.StoreUint32(&s.handler.bootstrapping, 0)
+
+	if err != nil {
+		log.Error("Failed to bootstrap the chain from a checkpoint", "err", err)
+		return
+	}
+
+	last := s.bootstrapVerifiedCheckpoint(number)
+	if last <= 1 {
+		return
+	}
+
+	log.Info("Verifying older checkpoints in the background", "first", 1, "last", last-1)
+
+	if err := s.verifyBootstrapCheckpoints(bor, last-1, 1); errors.Is(err, errBootstrapMismatch) {
+		atomic.StoreUint32(&s.handler.bootstrapping, 1)
+		s.haltCheckpointBootstrap(h, err)
+	}
+}
+
+// bootstrapFromCheckpoint starts the beacon sync from the anchor of the latest
+// checkpoint and waits until the local chain reaches it and the blocks
+// backfilled before it are verified against the configured number of preceding
+// checkpoints. It returns the number of the checkpoint, or 0 if the node was
+// stopped before the bootstrap completed.
+func (s *Ethereum) bootstrapFromCheckpoint(h *ethHandler, bor *bor.Bor) (int64, error) {
+	var (
+		number int64
+		anchor *types.Header
+		err    error
+	)
+
+	for anchor == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), whitelistTimeout)
+		number, anchor, err = fetchBootstrapAnchor(ctx, h, bor)
+
+		cancel()
+
+		if err != nil {
+			log.Warn("Failed to fetch the checkpoint to bootstrap from", "err", err)
+
+			select {
+			case <-time.After(bootstrapRetryDelay):
+			case <-s.closeCh:
+				return 0, nil
+			}
+		}
+	}
+
+	log.Info("Bootstrapping the chain from a checkpoint", "checkpoint", number, "number", anchor.Number, "hash", anchor.Hash())
+
+	if err := h.downloader.BeaconSync(downloader.SnapSync, anchor, nil); err != nil {
+		return 0, err
+	}
+
+	verified := make(chan error, 1)
+	go func() {
+		verified <- s.verifyBootstrapCheckpoints(bor, number-1, s.bootstrapVerifiedCheckpoint(number))
+	}()
+
+	ticker := time.NewTicker(bootstrapPollInterval)
+	defer ticker.Stop()
+
+	for verifying := true; verifying || s.blockchain.CurrentBlock().Number.Uint64() < anchor.Number.Uint64(); {
+		select {
+		case err := <-verified:
+			if err != nil {
+				return 0, err
+			}
+
+			verifying = false
+		case <-ticker.C:
+		case <-s.closeCh:
+			return 0, nil
+		}
+	}
+
+	log.Info("Bootstrapped the chain from a checkpoint", "checkpoint", number, "number", anchor.Number, "hash", anchor.Hash())
+
+	return number, nil
+}
+
+// bootstrapVerifiedCheckpoint returns the oldest checkpoint verified before the
+// legacy sync is enabled when bootstrapping from the given checkpoint. All the
+// preceding checkpoints are verified if the configured number is 0.
+func (s *Ethereum) bootstrapVerifiedCheckpoint(number int64) int64 {
+	if verify := int64(s.config.HeimdallBootstrapVerify); verify > 0 && number-verify > 1 {
+		return number - verify
+	}
+
+	return 1
+}
+
+// haltCheckpointBootstrap stops the bootstrap once the backfilled blocks turn
+// out to mismatch heimdall. The downloader is terminated so that nothing else
+// gets imported, and the local chain is rewound to genesis so that a restart
+// bootstraps again rather than syncing on top of these blocks. The legacy sync
+// stays disabled.
+func (s *Ethereum) haltCheckpointBootstrap(h *ethHandler, err error) {
+	bootstrapHaltedGauge.Update(1)
+	log.Error("Halted the checkpoint bootstrap, the node needs to be restarted", "err", err)
+
+	h.downloader.Terminate()
+
+	if err := s.blockchain.SetHead(0); err != nil {
+		log.Error("Failed to rewind the bootstrapped chain", "err", err)
+	}
+}
+
+// fetchBootstrapAnchor fetches the latest checkpoint from heimdall and returns
+// its number with the header of its end block. The blocks of the checkpoint are
+// fetched from the peers, the ones serving blocks mismatching the root hash of
+// the checkpoint are dropped.
+func fetchBootstrapAnchor(ctx context.Context, h *ethHandler, bor *bor.Bor) (int64, *types.Header, error) {
+	number, err := bor.HeimdallClient.FetchCheckpointCount(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if number <= 0 {
+		return 0, nil, errCheckpoint
+	}
+
+	checkpoint, err := bor.HeimdallClient.FetchCheckpoint(ctx, number)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	start, end := checkpoint.StartBlock.Uint64(), checkpoint.EndBlock.Uint64()
+
+	for _, peer := range h.peers.allPeers() {
+		headers, err := h.downloader.FetchHeaders(peer.ID(), start, end)
+		if err != nil {
+			log.Debug("Failed to fetch the blocks of the checkpoint", "peer", peer.ID(), "start", start, "end", end, "err", err)
+			continue
+		}
+
+		if err := verifyCheckpointHeaders(headers, checkpoint); err != nil {
+			log.Warn("Dropping peer serving blocks mismatching the checkpoint", "peer", peer.ID(), "checkpoint", number, "err", err)
+			(*handler)(h).removePeer(peer.ID())
+
+			continue
+		}
+
+		return number, headers[len(headers)-1], nil
+	}
+
+	return 0, nil, errBootstrapAnchor
+}
+
+// verifyBootstrapCheckpoints checks the blocks backfilled before the anchor of
+// the bootstrap against the root hashes of the checkpoints from first down to
+// last, as they become available. It returns errBootstrapMismatch on the first
+// checkpoint mismatching the backfilled blocks.
+func (s *Ethereum) verifyBootstrapCheckpoints(bor *bor.Bor, first int64, last int64) error {
+	ticker := time.NewTicker(bootstrapPollInterval)
+	defer ticker.Stop()
+
+	for n := first; n >= last; n-- {
+		var (
+			checkpoint *checkpoint.Checkpoint
+			headers    []*types.Header
+			err        error
+		)
+
+		for checkpoint == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), whitelistTimeout)
+			checkpoint, err = bor.HeimdallClient.FetchCheckpoint(ctx, n)
+
+			cancel()
+
+			if err != nil {
+				log.Debug("Failed to fetch checkpoint to verify the backfilled blocks", "number", n, "err", err)
+			}
+
+			if checkpoint == nil {
+				select {
+				case <-ticker.C:
+				case <-s.closeCh:
+					return errChainRecoveryStopped
+				}
+			}
+		}
+
+		for headers == nil {
+			if headers = s.backfilledHeaders(checkpoint.StartBlock.Uint64(), checkpoint.EndBlock.Uint64()); headers == nil {
+				select {
+				case <-ticker.C:
+				case <-s.closeCh:
+					return errChainRecoveryStopped
+				}
+			}
+		}
+
+		if err := verifyCheckpointHeaders(headers, checkpoint); err != nil {
+			bootstrapMismatchMeter.Mark(1)
+			log.Error("Backfilled blocks mismatch checkpoint", "number", n, "err", err)
+
+			return fmt.Errorf("%w %d: %v", errBootstrapMismatch, n, err)
+		}
+
+		bootstrapVerifiedMeter.Mark(1)
+		log.Debug("Verified backfilled blocks against checkpoint", "number", n, "start", checkpoint.StartBlock, "end", checkpoint.EndBlock)
+	}
+
+	log.Info("Verified backfilled blocks against checkpoints", "first", last, "last", first)
+
+	return nil
+}
+
+// backfilledHeaders returns the headers in the range [start, end], either held
+// by the skeleton of the beacon sync or imported in the local chain. It returns
+// nil if any of them isn't backfilled yet.
+func (s *Ethereum) backfilledHeaders(start uint64, end uint64) []*types.Header {
+	headers := make([]*types.Header, 0, end-start+1)
+
+	for number := start; number <= end; number++ {
+		header := s.blockchain.GetHeaderByNumber(number)
+		if header == nil {
+			header = s.handler.downloader.SkeletonHeader(number)
+		}
+
+		if header == nil {
+			return nil
+		}
+
+		headers = append(headers, header)
+	}
+
+	return headers
+}
+
+// verifyCheckpointHeaders checks that the headers are the consecutive blocks of
+// the checkpoint, and that their root hash matches the one of the checkpoint.
+func verifyCheckpointHeaders(headers []*types.Header, checkpoint *checkpoint.Checkpoint) error {
+	start, end := checkpoint.StartBlock.Uint64(), checkpoint.EndBlock.Uint64()
+
+	if uint64(len(headers)) != end-start+1 {
+		return fmt.Errorf("got %d headers for blocks %d-%d", len(headers), start, end)
+	}
+
+	for i, header := range headers {
+		if header.Number.Uint64() != start+uint64(i) {
+			return fmt.Errorf("got header %d for block %d", header.Number, start+uint64(i))
+		}
+
+		if i > 0 && header.ParentHash != headers[i-1].Hash() {
+			return fmt.Errorf("header %d isn't linked to its parent", header.Number)
+		}
+	}
+
+	root, err := bor.ComputeRootHash(headers)
+	if err != nil {
+		return err
+	}
+
+	if root != checkpoint.RootHash.String()[2:] {
+		return fmt.Errorf("%w: root hash %s, checkpoint root hash %s", errHashMismatch, root, checkpoint.RootHash.String()[2:])
+	}
+
+	return nil
+}
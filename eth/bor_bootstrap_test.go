@@ -0,0 +1,223 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+func TestVerifyCheckpointHeaders(t *testing.T) {
+	t.Parallel()
+
+	headers := make([]*types.Header, 0, 8)
+	for number := int64(8); number < 16; number++ {
+		header := &types.Header{Number: big.NewInt(number), Time: uint64(number) * 2}
+		if len(headers) > 0 {
+			header.ParentHash = headers[len(headers)-1].Hash()
+		}
+
+		headers = append(headers, header)
+	}
+
+	root, err := bor.ComputeRootHash(headers)
+	require.NoError(t, err)
+
+	cp := &checkpoint.Checkpoint{
+		StartBlock: big.NewInt(8),
+		EndBlock:   big.NewInt(15),
+		RootHash:   common.HexToHash(root),
+	}
+
+	require.NoError(t, verifyCheckpointHeaders(headers, cp))
+
+	// Missing blocks
+	require.Error(t, verifyCheckpointHeaders(headers[1:], cp))
+
+	// Blocks not linked to their parent
+	unlinked := append([]*types.Header{}, headers...)
+	unlinked[4] = &types.Header{Number: big.NewInt(12), Time: 24}
+	require.Error(t, verifyCheckpointHeaders(unlinked, cp))
+
+	// Blocks of another chain
+	cp.RootHash = common.Hash{0x1}
+	require.True(t, errors.Is(verifyCheckpointHeaders(headers, cp), errHashMismatch))
+}
+
+// Tests that a new node syncs the blocks up to the anchor of the latest
+// checkpoint with a beacon sync, and only enables the legacy sync once they are
+// verified against the preceding checkpoints.
+func TestCheckpointBootstrap(t *testing.T) {
+	eth, heimdall, remote := newBootstrapTestBackend(t)
+
+	done := make(chan struct{})
+	go func() {
+		eth.runCheckpointBootstrap((*ethHandler)(eth.handler), &bor.Bor{HeimdallClient: heimdall})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("bootstrap timed out")
+	}
+
+	require.Equal(t, uint32(0), atomic.LoadUint32(&eth.handler.bootstrapping))
+	require.Equal(t, remote.chain.CurrentBlock().Hash(), eth.blockchain.CurrentBlock().Hash())
+}
+
+// Tests that the bootstrap is halted, with the legacy sync disabled and the
+// chain rewound, if the backfilled blocks mismatch a checkpoint.
+func TestCheckpointBootstrapMismatch(t *testing.T) {
+	eth, heimdall, _ := newBootstrapTestBackend(t)
+
+	fetchCheckpoint := heimdall.fetchCheckpoint
+	heimdall.fetchCheckpoint = func(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+		cp, err := fetchCheckpoint(ctx, number)
+		if number == 1 {
+			cp.RootHash = common.Hash{0x1}
+		}
+
+		return cp, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		eth.runCheckpointBootstrap((*ethHandler)(eth.handler), &bor.Bor{HeimdallClient: heimdall})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("bootstrap timed out")
+	}
+
+	require.Equal(t, uint32(1), atomic.LoadUint32(&eth.handler.bootstrapping))
+	require.Zero(t, eth.blockchain.CurrentBlock().Number.Uint64())
+
+	// The downloader is terminated
+	require.Error(t, eth.handler.downloader.BeaconSync(0, eth.blockchain.CurrentHeader(), nil))
+}
+
+// Tests that the checkpoints older than the configured number are verified
+// once the legacy sync is enabled, and that the bootstrap is halted if the
+// backfilled blocks mismatch one of them.
+func TestCheckpointBootstrapBackgroundMismatch(t *testing.T) {
+	eth, heimdall, _ := newBootstrapTestBackend(t)
+	eth.config.HeimdallBootstrapVerify = 1
+
+	enabled := make(chan struct{})
+
+	fetchCheckpoint := heimdall.fetchCheckpoint
+	heimdall.fetchCheckpoint = func(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+		cp, err := fetchCheckpoint(ctx, number)
+		if number == 1 {
+			if atomic.LoadUint32(&eth.handler.bootstrapping) == 0 {
+				select {
+				case <-enabled:
+				default:
+					close(enabled)
+				}
+			}
+
+			cp.RootHash = common.Hash{0x1}
+		}
+
+		return cp, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		eth.runCheckpointBootstrap((*ethHandler)(eth.handler), &bor.Bor{HeimdallClient: heimdall})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("bootstrap timed out")
+	}
+
+	// The oldest checkpoint is only verified once the legacy sync is enabled
+	select {
+	case <-enabled:
+	default:
+		t.Fatal("oldest checkpoint verified before enabling the legacy sync")
+	}
+
+	require.Equal(t, uint32(1), atomic.LoadUint32(&eth.handler.bootstrapping))
+	require.Zero(t, eth.blockchain.CurrentBlock().Number.Uint64())
+}
+
+// newBootstrapTestBackend creates an empty node bootstrapping from a peer
+// serving 32 blocks, checkpointed by heimdall in four quarters.
+func newBootstrapTestBackend(t *testing.T) (*Ethereum, *mockHeimdall, *testHandler) {
+	t.Helper()
+
+	retryDelay, pollInterval := bootstrapRetryDelay, bootstrapPollInterval
+	bootstrapRetryDelay, bootstrapPollInterval = 50*time.Millisecond, 50*time.Millisecond
+
+	t.Cleanup(func() {
+		bootstrapRetryDelay, bootstrapPollInterval = retryDelay, pollInterval
+	})
+
+	local := newTestHandler()
+	t.Cleanup(local.close)
+
+	remote := newTestHandlerWithBlocks(32)
+	t.Cleanup(remote.close)
+
+	atomic.StoreUint32(&local.handler.bootstrapping, 1)
+
+	connectTestPeer(t, local, remote, enode.ID{1})
+
+	checkpoints := make(map[int64]*checkpoint.Checkpoint)
+
+	for number, start := int64(1), uint64(1); start < 32; number, start = number+1, start+8 {
+		headers := make([]*types.Header, 0, 8)
+		for n := start; n < start+8; n++ {
+			headers = append(headers, remote.chain.GetHeaderByNumber(n))
+		}
+
+		root, err := bor.ComputeRootHash(headers)
+		require.NoError(t, err)
+
+		checkpoints[number] = &checkpoint.Checkpoint{
+			StartBlock: new(big.Int).SetUint64(start),
+			EndBlock:   new(big.Int).SetUint64(start + 7),
+			RootHash:   common.HexToHash(root),
+		}
+	}
+
+	heimdall := &mockHeimdall{
+		fetchCheckpointCount: func(ctx context.Context) (int64, error) {
+			return int64(len(checkpoints)), nil
+		},
+		fetchCheckpoint: func(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+			cp := *checkpoints[number]
+			return &cp, nil
+		},
+	}
+
+	eth := &Ethereum{
+		handler:    local.handler,
+		blockchain: local.chain,
+		config:     &ethconfig.Config{},
+		closeCh:    make(chan struct{}),
+	}
+	t.Cleanup(func() { close(eth.closeCh) })
+
+	return eth, heimdall, remote
+}
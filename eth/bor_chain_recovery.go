@@ -0,0 +1,269 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	chainRecoveryAttempts   = 5                // Number of times the peers are validated and synced from
+	chainRecoveryRetryDelay = 10 * time.Second // Delay between the attempts of a recovery
+)
+
+var (
+	errChainRecoveryStopped = errors.New("node stopped")
+	errChainNotRecovered    = errors.New("no peer served the chain of heimdall")
+
+	chainRecoveryStartedMeter      = metrics.NewRegisteredMeter("chain/recovery/started", nil)
+	chainRecoverySucceededMeter    = metrics.NewRegisteredMeter("chain/recovery/succeeded", nil)
+	chainRecoveryFailedMeter       = metrics.NewRegisteredMeter("chain/recovery/failed", nil)
+	chainRecoveryDroppedPeersMeter = metrics.NewRegisteredMeter("chain/recovery/droppedpeers", nil)
+)
+
+// recoveryTarget is the milestone or the checkpoint the local chain mismatched.
+// The hash is hex encoded without prefix, as received by borVerify.
+type recoveryTarget struct {
+	start        uint64
+	end          uint64
+	hash         string
+	isCheckpoint bool
+}
+
+// chainRecovery recovers the chain once it was rewound after a mismatch with a
+// milestone or a checkpoint. Rather than waiting for the regular sync to pick
+// the right fork, the peers serving headers conflicting with the whitelisted
+// blocks, with the end block of the milestone or with the root hash of the
+// checkpoint, are dropped and the chain is synced from the remaining ones. A
+// single recovery runs at a time.
+type chainRecovery struct {
+	status *types.ChainRecovery // Status of the last recovery, nil if none was started
+	lock   sync.RWMutex
+
+	attempts   int
+	retryDelay time.Duration
+}
+
+func newChainRecovery() *chainRecovery {
+	return &chainRecovery{
+		attempts:   chainRecoveryAttempts,
+		retryDelay: chainRecoveryRetryDelay,
+	}
+}
+
+// Status returns the status of the last recovery, nil if none was started.
+func (r *chainRecovery) Status() *types.ChainRecovery {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if r.status == nil {
+		return nil
+	}
+
+	status := *r.status
+
+	return &status
+}
+
+// start launches the recovery of the chain for the given target, unless a
+// recovery is already running.
+func (r *chainRecovery) start(eth *Ethereum, target recoveryTarget) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.status != nil && r.status.Status == types.ChainRecoveryRunning {
+		log.Debug("Chain recovery already running", "end", r.status.EndBlock)
+		return
+	}
+
+	source := types.FinalityMilestone
+	if target.isCheckpoint {
+		source = types.FinalityCheckpoint
+	}
+
+	r.status = &types.ChainRecovery{
+		Status:     types.ChainRecoveryRunning,
+		Source:     source,
+		StartBlock: target.start,
+		EndBlock:   target.end,
+		Hash:       "0x" + target.hash,
+		Started:    time.Now(),
+	}
+
+	chainRecoveryStartedMeter.Mark(1)
+
+	log.Warn("Starting chain recovery", "source", source, "start", target.start, "end", target.end, "hash", r.status.Hash)
+
+	go r.run(eth, target)
+}
+
+func (r *chainRecovery) run(eth *Ethereum, target recoveryTarget) {
+	// The end block hash of a milestone is checked against the peers with the
+	// whitelisted blocks, the blocks of a checkpoint against its root hash
+	var hash common.Hash
+	if !target.isCheckpoint {
+		hash = common.HexToHash(target.hash)
+	}
+
+	for attempt := 0; attempt < r.attempts; attempt++ {
+		if recovered(eth, target) {
+			r.finish(nil)
+			return
+		}
+
+		peers, dropped := eth.handler.downloader.ValidatePeers(target.end, hash)
+
+		if target.isCheckpoint {
+			var droppedCheckpoint int
+
+			peers, droppedCheckpoint = validateCheckpointPeers(eth, peers, target)
+			dropped += droppedCheckpoint
+		}
+
+		chainRecoveryDroppedPeersMeter.Mark(int64(dropped))
+
+		r.lock.Lock()
+		r.status.Attempts++
+		r.status.ValidPeers = len(peers)
+		r.status.DroppedPeers += dropped
+		r.lock.Unlock()
+
+		log.Info("Validated peers for chain recovery", "attempt", attempt+1, "valid", len(peers), "dropped", dropped)
+
+		// Sync from the valid peer with the highest difficulty, through the chain
+		// syncer so that the regular syncs and the bootstrap are waited for
+		if peer := bestPeer(eth, peers); peer != nil && !eth.handler.chainSync.requestSync(peer.Peer) {
+			r.finish(errChainRecoveryStopped)
+			return
+		}
+
+		select {
+		case <-time.After(r.retryDelay):
+		case <-eth.closeCh:
+			r.finish(errChainRecoveryStopped)
+			return
+		}
+	}
+
+	if recovered(eth, target) {
+		r.finish(nil)
+		return
+	}
+
+	r.finish(errChainNotRecovered)
+}
+
+// validateCheckpointPeers fetches the blocks of the checkpoint from the given
+// peers and checks them against its root hash. The peers serving mismatching
+// blocks are dropped, the ids of the ones serving the blocks of the checkpoint
+// are returned with the number of dropped peers. The peers which don't serve
+// the blocks are ignored.
+func validateCheckpointPeers(eth *Ethereum, ids []string, target recoveryTarget) ([]string, int) {
+	var (
+		valid   []string
+		dropped int
+	)
+
+	checkpoint := &checkpoint.Checkpoint{
+		StartBlock: new(big.Int).SetUint64(target.start),
+		EndBlock:   new(big.Int).SetUint64(target.end),
+		RootHash:   common.HexToHash(target.hash),
+	}
+
+	for _, id := range ids {
+		headers, err := eth.handler.downloader.FetchHeaders(id, target.start, target.end)
+		if err != nil {
+			log.Debug("Failed to fetch the blocks of the checkpoint", "peer", id, "start", target.start, "end", target.end, "err", err)
+			continue
+		}
+
+		if err := verifyCheckpointHeaders(headers, checkpoint); err != nil {
+			log.Warn("Dropping peer serving blocks mismatching the checkpoint", "peer", id, "start", target.start, "end", target.end, "err", err)
+			eth.handler.removePeer(id)
+
+			dropped++
+
+			continue
+		}
+
+		valid = append(valid, id)
+	}
+
+	return valid, dropped
+}
+
+func (r *chainRecovery) finish(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+
+	r.status.Finished = &now
+
+	if err != nil {
+		r.status.Status = types.ChainRecoveryFailed
+		r.status.Error = err.Error()
+
+		chainRecoveryFailedMeter.Mark(1)
+
+		log.Warn("Chain recovery failed", "end", r.status.EndBlock, "attempts", r.status.Attempts, "err", err)
+
+		return
+	}
+
+	r.status.Status = types.ChainRecoverySucceeded
+
+	chainRecoverySucceededMeter.Mark(1)
+
+	log.Info("Chain recovered", "end", r.status.EndBlock, "attempts", r.status.Attempts, "elapsed", now.Sub(r.status.Started))
+}
+
+// bestPeer returns the peer with the highest difficulty out of the given ones.
+func bestPeer(eth *Ethereum, ids []string) *ethPeer {
+	var (
+		best   *ethPeer
+		bestTd *big.Int
+	)
+
+	for _, id := range ids {
+		peer := eth.handler.peers.peer(id)
+		if peer == nil {
+			continue
+		}
+
+		if _, td := peer.Head(); bestTd == nil || td.Cmp(bestTd) > 0 {
+			best, bestTd = peer, td
+		}
+	}
+
+	return best
+}
+
+// recovered reports whether the local chain matches the target.
+func recovered(eth *Ethereum, target recoveryTarget) bool {
+	if eth.blockchain.CurrentBlock().Number.Uint64() < target.end {
+		return false
+	}
+
+	if !target.isCheckpoint {
+		header := eth.blockchain.GetHeaderByNumber(target.end)
+
+		return header != nil && header.Hash() == common.HexToHash(target.hash)
+	}
+
+	localHash, err := eth.handler.ethAPI.GetRootHash(context.Background(), target.start, target.end)
+	if err != nil {
+		log.Debug("Failed to get root hash while recovering the chain", "start", target.start, "end", target.end, "err", err)
+		return false
+	}
+
+	return localHash == target.hash
+}
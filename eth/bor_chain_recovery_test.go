@@ -0,0 +1,154 @@
+package eth
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// Tests that the peers serving blocks mismatching the root hash of a checkpoint
+// are dropped while recovering the chain.
+func TestValidateCheckpointPeers(t *testing.T) {
+	t.Parallel()
+
+	local := newTestHandler()
+	t.Cleanup(local.close)
+
+	good := newTestHandlerWithBlocks(32)
+	t.Cleanup(good.close)
+
+	// The bad peer serves another chain with the same genesis
+	bad := newTestHandler()
+	t.Cleanup(bad.close)
+
+	blocks, _ := core.GenerateChain(bad.chain.Config(), bad.chain.Genesis(), ethash.NewFaker(), bad.db, 32, func(i int, gen *core.BlockGen) {
+		gen.OffsetTime(5)
+	})
+
+	_, err := bad.chain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	goodID := connectTestPeer(t, local, good, enode.ID{1})
+	badID := connectTestPeer(t, local, bad, enode.ID{2})
+
+	// Wait a bit for the handshakes
+	time.Sleep(250 * time.Millisecond)
+
+	headers := make([]*types.Header, 0, 16)
+	for number := uint64(1); number <= 16; number++ {
+		headers = append(headers, good.chain.GetHeaderByNumber(number))
+	}
+
+	root, err := bor.ComputeRootHash(headers)
+	require.NoError(t, err)
+
+	eth := &Ethereum{handler: local.handler, blockchain: local.chain}
+	target := recoveryTarget{start: 1, end: 16, hash: root, isCheckpoint: true}
+
+	valid, dropped := validateCheckpointPeers(eth, []string{goodID, badID}, target)
+	require.Equal(t, []string{goodID}, valid)
+	require.Equal(t, 1, dropped)
+
+	// The peers not serving the blocks are ignored
+	valid, dropped = validateCheckpointPeers(eth, []string{common.Hash{0x3}.Hex()[2:]}, target)
+	require.Empty(t, valid)
+	require.Zero(t, dropped)
+}
+
+// connectTestPeer connects the remote handler to the local one, it returns the
+// id of the remote peer.
+func connectTestPeer(t *testing.T, local *testHandler, remote *testHandler, id enode.ID) string {
+	t.Helper()
+
+	caps := []p2p.Cap{{Name: "eth", Version: eth.ETH68}}
+
+	localPipe, remotePipe := p2p.MsgPipe()
+	t.Cleanup(func() {
+		localPipe.Close()
+		remotePipe.Close()
+	})
+
+	remotePeer := eth.NewPeer(eth.ETH68, p2p.NewPeer(id, "", caps), localPipe, local.txpool)
+	localPeer := eth.NewPeer(eth.ETH68, p2p.NewPeer(enode.ID{0xff}, "", caps), remotePipe, remote.txpool)
+
+	t.Cleanup(func() {
+		remotePeer.Close()
+		localPeer.Close()
+	})
+
+	go local.handler.runEthPeer(remotePeer, func(peer *eth.Peer) error {
+		return eth.Handle((*ethHandler)(local.handler), peer)
+	})
+	go remote.handler.runEthPeer(localPeer, func(peer *eth.Peer) error {
+		return eth.Handle((*ethHandler)(remote.handler), peer)
+	})
+
+	return remotePeer.ID()
+}
+
+// Tests that a sync requested from a peer is started once nothing prevents it,
+// regardless of the number of peers.
+func TestChainSyncerRequestedPeer(t *testing.T) {
+	t.Parallel()
+
+	local := newTestHandler()
+	t.Cleanup(local.close)
+
+	remote := newTestHandlerWithBlocks(1)
+	t.Cleanup(remote.close)
+
+	id := connectTestPeer(t, local, remote, enode.ID{1})
+
+	// Wait a bit for the handshake
+	time.Sleep(250 * time.Millisecond)
+
+	peer := local.handler.peers.peer(id)
+	require.NotNil(t, peer)
+
+	cs := newChainSyncer(local.handler)
+
+	// A single peer isn't enough for a regular sync
+	require.Nil(t, cs.nextSyncOp())
+
+	// The requested sync waits for the end of the bootstrap
+	cs.syncPeer = peer.Peer
+	atomic.StoreUint32(&local.handler.bootstrapping, 1)
+
+	require.Nil(t, cs.nextSyncOp())
+	require.Equal(t, peer.Peer, cs.syncPeer)
+
+	atomic.StoreUint32(&local.handler.bootstrapping, 0)
+
+	op := cs.nextSyncOp()
+	require.NotNil(t, op)
+	require.Equal(t, peer.Peer, op.peer)
+	require.Nil(t, cs.syncPeer)
+
+	mode, _ := cs.modeAndLocalHead()
+	require.Equal(t, mode, op.mode)
+
+	require.Nil(t, cs.nextSyncOp())
+
+	// A sync requested from a disconnected peer is skipped
+	pipe, _ := p2p.MsgPipe()
+	defer pipe.Close()
+
+	disconnected := eth.NewPeer(eth.ETH68, p2p.NewPeer(enode.ID{2}, "", nil), pipe, nil)
+	defer disconnected.Close()
+
+	cs.syncPeer = disconnected
+
+	require.Nil(t, cs.nextSyncOp())
+	require.Nil(t, cs.syncPeer)
+}
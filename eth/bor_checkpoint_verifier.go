@@ -129,6 +129,8 @@ func borVerify(ctx context.Context, eth *Ethereum, handler *ethHandler, start ui
 
 		rewindBack(eth, head, rewindTo)
 
+		eth.chainRecovery.start(eth, recoveryTarget{start: start, end: end, hash: hash, isCheckpoint: isCheckpoint})
+
 		return hash, errHashMismatch
 	}
 
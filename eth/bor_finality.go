@@ -0,0 +1,198 @@
+package eth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	errNoFinalizedBlock = errors.New("finalized block not found")
+	errNoSafeBlock      = errors.New("safe block not found")
+)
+
+// BorAPI provides the finality of the chain, as whitelisted from heimdall, and
+// the votes of the node on the milestones.
+type BorAPI struct {
+	eth *Ethereum
+}
+
+// NewBorAPI creates a new BorAPI instance.
+func NewBorAPI(eth *Ethereum) *BorAPI {
+	return &BorAPI{eth: eth}
+}
+
+// GetFinality returns the blocks the 'finalized' and 'safe' block tags resolve
+// to, with the heimdall ids of the milestone and checkpoint they're resolved from.
+// The status of the last recovery of the chain after a mismatch with heimdall is
+// returned as well.
+func (api *BorAPI) GetFinality() *types.Finality {
+	finality := new(types.Finality)
+
+	finality.Finalized, _ = finalizedBlock(api.eth)
+	finality.Safe, _ = safeBlock(api.eth)
+	finality.Recovery = api.eth.chainRecovery.Status()
+
+	return finality
+}
+
+// FinalizedHeads creates a subscription sending the header of the end block of
+// every milestone and checkpoint whitelisted from now on, which advances the
+// finality of the chain.
+func (api *BorAPI) FinalizedHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		blocks := make(chan *types.FinalityBlock, 16)
+		sub := api.eth.Downloader().SubscribeFinality(blocks)
+
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case block := <-blocks:
+				header := api.eth.blockchain.GetHeader(block.Hash, block.Number)
+				if header == nil {
+					log.Debug("Finalized header not found", "number", block.Number, "hash", block.Hash)
+					continue
+				}
+
+				_ = notifier.Notify(rpcSub.ID, &types.FinalizedHead{Header: header, FinalityBlock: block})
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// finalityHeader resolves the 'finalized' and 'safe' block tags. Once the merge
+// happened, the safe block is the one of the consensus client.
+func finalityHeader(eth *Ethereum, number rpc.BlockNumber) (*types.Header, error) {
+	if number == rpc.SafeBlockNumber && eth.Merger().TDDReached() {
+		header := eth.blockchain.CurrentSafeBlock()
+		if header == nil {
+			return nil, errNoSafeBlock
+		}
+
+		return header, nil
+	}
+
+	resolve, errNotFound := finalizedBlock, errNoFinalizedBlock
+	if number == rpc.SafeBlockNumber {
+		resolve, errNotFound = safeBlock, errNoSafeBlock
+	}
+
+	block, err := resolve(eth)
+	if err != nil {
+		return nil, err
+	}
+
+	header := eth.blockchain.GetHeader(block.Hash, block.Number)
+	if header == nil {
+		return nil, errNotFound
+	}
+
+	return header, nil
+}
+
+// finalizedBlock returns the end block of the last whitelisted milestone, or of
+// the last whitelisted checkpoint if no milestone was whitelisted yet.
+func finalizedBlock(eth *Ethereum) (*types.FinalityBlock, error) {
+	if block := whitelistedMilestone(eth); block != nil {
+		return block, nil
+	}
+
+	if block := whitelistedCheckpoint(eth); block != nil {
+		return block, nil
+	}
+
+	return nil, errNoFinalizedBlock
+}
+
+// safeBlock returns the safe block of the configured policy. With the milestone
+// policy, a block with enough confirmations is safe if it is newer than the
+// finalized one.
+func safeBlock(eth *Ethereum) (*types.FinalityBlock, error) {
+	if eth.config.RPCSafeBlock == ethconfig.SafeBlockCheckpoint {
+		if block := whitelistedCheckpoint(eth); block != nil {
+			return block, nil
+		}
+
+		return nil, errNoSafeBlock
+	}
+
+	block, err := finalizedBlock(eth)
+
+	confirmations := eth.config.RPCSafeBlockConfirmations
+	if head := eth.blockchain.CurrentBlock().Number.Uint64(); confirmations > 0 && head >= confirmations {
+		if number := head - confirmations; block == nil || number > block.Number {
+			if header := eth.blockchain.GetHeaderByNumber(number); header != nil {
+				return &types.FinalityBlock{Number: number, Hash: header.Hash(), Source: types.FinalityConfirmations}, nil
+			}
+		}
+	}
+
+	if err != nil {
+		return nil, errNoSafeBlock
+	}
+
+	return block, nil
+}
+
+// whitelistedMilestone returns the end block of the last whitelisted milestone,
+// nil if there is none or it isn't part of the canonical chain.
+func whitelistedMilestone(eth *Ethereum) *types.FinalityBlock {
+	doExist, number, hash := eth.Downloader().GetWhitelistedMilestone()
+	if !doExist || !isCanonical(eth, number, hash) {
+		return nil
+	}
+
+	return &types.FinalityBlock{
+		Number:      number,
+		Hash:        hash,
+		Source:      types.FinalityMilestone,
+		MilestoneID: eth.Downloader().GetWhitelistedMilestoneID(),
+	}
+}
+
+// whitelistedCheckpoint returns the end block of the last whitelisted checkpoint,
+// nil if there is none or it isn't part of the canonical chain.
+func whitelistedCheckpoint(eth *Ethereum) *types.FinalityBlock {
+	doExist, number, hash := eth.Downloader().GetWhitelistedCheckpoint()
+	if !doExist || !isCanonical(eth, number, hash) {
+		return nil
+	}
+
+	return &types.FinalityBlock{
+		Number:           number,
+		Hash:             hash,
+		Source:           types.FinalityCheckpoint,
+		CheckpointNumber: eth.Downloader().GetWhitelistedCheckpointNumber(),
+	}
+}
+
+func isCanonical(eth *Ethereum, number uint64, hash common.Hash) bool {
+	if number > eth.blockchain.CurrentBlock().Number.Uint64() {
+		return false
+	}
+
+	header := eth.blockchain.GetHeaderByNumber(number)
+
+	return header != nil && header.Hash() == hash
+}
@@ -0,0 +1,206 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// newFinalityTestBackend creates a backend with a chain of the given number of
+// blocks, and a whitelist service to whitelist milestones and checkpoints in.
+func newFinalityTestBackend(t *testing.T, blocks int, config *ethconfig.Config) *Ethereum {
+	t.Helper()
+
+	db := rawdb.NewMemoryDatabase()
+	gspec := &core.Genesis{Config: params.TestChainConfig}
+
+	_, bs, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), blocks, nil)
+
+	chain, err := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	require.NoError(t, err)
+
+	_, err = chain.InsertChain(bs)
+	require.NoError(t, err)
+
+	d := downloader.New(0, db, new(event.TypeMux), chain, nil, func(string) {}, nil, whitelist.NewService(db))
+
+	t.Cleanup(func() {
+		d.Terminate()
+		chain.Stop()
+	})
+
+	return &Ethereum{
+		config:     config,
+		blockchain: chain,
+		handler:    &handler{downloader: d},
+		merger:     consensus.NewMerger(rawdb.NewMemoryDatabase()),
+	}
+}
+
+func whitelistMilestone(eth *Ethereum, number uint64, id string) common.Hash {
+	hash := eth.blockchain.GetHeaderByNumber(number).Hash()
+	eth.Downloader().ProcessMilestone(number, hash, id)
+
+	return hash
+}
+
+func whitelistCheckpoint(eth *Ethereum, number uint64, checkpointNumber uint64) common.Hash {
+	hash := eth.blockchain.GetHeaderByNumber(number).Hash()
+	eth.Downloader().ProcessCheckpoint(number, hash, checkpointNumber)
+
+	return hash
+}
+
+// Tests that the finalized block is the end block of the last milestone, or of
+// the last checkpoint until a milestone is whitelisted.
+func TestFinalizedBlock(t *testing.T) {
+	t.Parallel()
+
+	eth := newFinalityTestBackend(t, 20, &ethconfig.Config{RPCSafeBlock: ethconfig.SafeBlockMilestone})
+
+	_, err := finalizedBlock(eth)
+	require.ErrorIs(t, err, errNoFinalizedBlock)
+
+	_, err = finalityHeader(eth, rpc.FinalizedBlockNumber)
+	require.ErrorIs(t, err, errNoFinalizedBlock)
+
+	hash := whitelistCheckpoint(eth, 10, 1)
+
+	block, err := finalizedBlock(eth)
+	require.NoError(t, err)
+	require.Equal(t, &types.FinalityBlock{Number: 10, Hash: hash, Source: types.FinalityCheckpoint, CheckpointNumber: 1}, block)
+
+	hash = whitelistMilestone(eth, 15, "milestone-1")
+
+	block, err = finalizedBlock(eth)
+	require.NoError(t, err)
+	require.Equal(t, &types.FinalityBlock{Number: 15, Hash: hash, Source: types.FinalityMilestone, MilestoneID: "milestone-1"}, block)
+
+	header, err := finalityHeader(eth, rpc.FinalizedBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, hash, header.Hash())
+}
+
+// Tests that the safe block is the end block of the last checkpoint with the
+// checkpoint policy, regardless of the milestones and confirmations.
+func TestSafeBlockCheckpointPolicy(t *testing.T) {
+	t.Parallel()
+
+	eth := newFinalityTestBackend(t, 20, &ethconfig.Config{RPCSafeBlock: ethconfig.SafeBlockCheckpoint, RPCSafeBlockConfirmations: 3})
+
+	whitelistMilestone(eth, 15, "milestone-1")
+
+	_, err := safeBlock(eth)
+	require.ErrorIs(t, err, errNoSafeBlock)
+
+	_, err = finalityHeader(eth, rpc.SafeBlockNumber)
+	require.ErrorIs(t, err, errNoSafeBlock)
+
+	hash := whitelistCheckpoint(eth, 10, 1)
+
+	block, err := safeBlock(eth)
+	require.NoError(t, err)
+	require.Equal(t, &types.FinalityBlock{Number: 10, Hash: hash, Source: types.FinalityCheckpoint, CheckpointNumber: 1}, block)
+
+	header, err := finalityHeader(eth, rpc.SafeBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, hash, header.Hash())
+}
+
+// Tests that with the milestone policy, the safe block is the block with enough
+// confirmations if it is newer than the finalized one.
+func TestSafeBlockConfirmationsPolicy(t *testing.T) {
+	t.Parallel()
+
+	eth := newFinalityTestBackend(t, 20, &ethconfig.Config{RPCSafeBlock: ethconfig.SafeBlockMilestone, RPCSafeBlockConfirmations: 3})
+
+	// Without any finalized block, the confirmations are enough
+	block, err := safeBlock(eth)
+	require.NoError(t, err)
+	require.Equal(t, &types.FinalityBlock{Number: 17, Hash: eth.blockchain.GetHeaderByNumber(17).Hash(), Source: types.FinalityConfirmations}, block)
+
+	// An older milestone doesn't take over the confirmations
+	whitelistMilestone(eth, 15, "milestone-1")
+
+	block, err = safeBlock(eth)
+	require.NoError(t, err)
+	require.Equal(t, uint64(17), block.Number)
+	require.Equal(t, types.FinalityConfirmations, block.Source)
+
+	// A newer milestone does
+	hash := whitelistMilestone(eth, 19, "milestone-2")
+
+	block, err = safeBlock(eth)
+	require.NoError(t, err)
+	require.Equal(t, &types.FinalityBlock{Number: 19, Hash: hash, Source: types.FinalityMilestone, MilestoneID: "milestone-2"}, block)
+
+	header, err := finalityHeader(eth, rpc.SafeBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, hash, header.Hash())
+
+	// Without confirmations, only the finalized block is safe
+	eth = newFinalityTestBackend(t, 20, &ethconfig.Config{RPCSafeBlock: ethconfig.SafeBlockMilestone})
+
+	_, err = safeBlock(eth)
+	require.ErrorIs(t, err, errNoSafeBlock)
+
+	hash = whitelistMilestone(eth, 15, "milestone-1")
+
+	block, err = safeBlock(eth)
+	require.NoError(t, err)
+	require.Equal(t, &types.FinalityBlock{Number: 15, Hash: hash, Source: types.FinalityMilestone, MilestoneID: "milestone-1"}, block)
+}
+
+// Tests that the whitelisted blocks which aren't part of the canonical chain are
+// skipped, falling back to the next rule.
+func TestFinalityNonCanonical(t *testing.T) {
+	t.Parallel()
+
+	eth := newFinalityTestBackend(t, 20, &ethconfig.Config{RPCSafeBlock: ethconfig.SafeBlockMilestone})
+
+	checkpoint := whitelistCheckpoint(eth, 10, 1)
+
+	// A milestone of a sidechain falls back to the checkpoint
+	eth.Downloader().ProcessMilestone(15, common.HexToHash("0xdeadbeef"), "milestone-1")
+
+	block, err := finalizedBlock(eth)
+	require.NoError(t, err)
+	require.Equal(t, &types.FinalityBlock{Number: 10, Hash: checkpoint, Source: types.FinalityCheckpoint, CheckpointNumber: 1}, block)
+
+	// So does a milestone ahead of the local head
+	eth.Downloader().ProcessMilestone(25, common.HexToHash("0xdeadbeef"), "milestone-2")
+
+	block, err = finalizedBlock(eth)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), block.Number)
+	require.Equal(t, types.FinalityCheckpoint, block.Source)
+
+	// Without a canonical checkpoint either, there is no finalized block
+	eth.Downloader().ProcessCheckpoint(20, common.HexToHash("0xdeadbeef"), 2)
+
+	_, err = finalizedBlock(eth)
+	require.ErrorIs(t, err, errNoFinalizedBlock)
+
+	_, err = safeBlock(eth)
+	require.ErrorIs(t, err, errNoSafeBlock)
+
+	// The checkpoint policy doesn't fall back to anything
+	eth.config.RPCSafeBlock = ethconfig.SafeBlockCheckpoint
+
+	_, err = safeBlock(eth)
+	require.ErrorIs(t, err, errNoSafeBlock)
+}
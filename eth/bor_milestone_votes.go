@@ -0,0 +1,84 @@
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxMilestoneVotesRange is the maximum number of blocks whose milestone votes
+// are returned by GetMilestoneVotes
+const maxMilestoneVotesRange = 10000
+
+// milestoneVotesRetention is the number of blocks behind the head whose
+// milestone votes are kept, the votes on older milestones are pruned
+const milestoneVotesRetention = 100000
+
+// writeMilestoneVote adds the outcome of a vote request of heimdall to the
+// milestone votes audit log. The error rejecting the milestone is kept as the
+// reason of the rejection. The votes on the milestones ending more than
+// milestoneVotesRetention blocks behind the head are pruned.
+func writeMilestoneVote(db ethdb.KeyValueStore, head uint64, start uint64, end uint64, hash string, milestoneID string, voted bool, err error) {
+	vote := &types.MilestoneVote{
+		Time:        time.Now(),
+		StartBlock:  start,
+		EndBlock:    end,
+		Hash:        hash,
+		MilestoneID: milestoneID,
+		Vote:        voted,
+	}
+
+	if err != nil {
+		vote.Reason = err.Error()
+	}
+
+	data, err := json.Marshal(vote)
+	if err != nil {
+		log.Error("Failed to encode milestone vote", "end", end, "milestoneID", milestoneID, "err", err)
+		return
+	}
+
+	if err := rawdb.WriteMilestoneVote(db, end, uint64(vote.Time.UnixNano()), data); err != nil {
+		log.Error("Failed to write milestone vote", "end", end, "milestoneID", milestoneID, "err", err)
+	}
+
+	if head > milestoneVotesRetention {
+		if err := rawdb.DeleteMilestoneVotes(db, head-milestoneVotesRetention); err != nil {
+			log.Error("Failed to prune milestone votes", "head", head, "err", err)
+		}
+	}
+}
+
+// readMilestoneVotes returns the votes on the milestones ending in the range
+// [from, to], ordered by end block and time.
+func readMilestoneVotes(db ethdb.Iteratee, from uint64, to uint64) []*types.MilestoneVote {
+	votes := make([]*types.MilestoneVote, 0)
+
+	for _, data := range rawdb.ReadMilestoneVotes(db, from, to) {
+		vote := new(types.MilestoneVote)
+		if err := json.Unmarshal(data, vote); err != nil {
+			log.Error("Invalid milestone vote JSON", "err", err)
+			continue
+		}
+
+		votes = append(votes, vote)
+	}
+
+	return votes
+}
+
+// GetMilestoneVotes returns the answers of the node to the vote requests of
+// heimdall on the milestones ending in the range [from, to], with the reason of
+// the rejected ones.
+func (api *BorAPI) GetMilestoneVotes(from uint64, to uint64) ([]*types.MilestoneVote, error) {
+	if from > to || to-from >= maxMilestoneVotesRange {
+		return nil, fmt.Errorf("invalid block range %d-%d, at most %d blocks can be requested", from, to, maxMilestoneVotesRange)
+	}
+
+	return readMilestoneVotes(api.eth.ChainDb(), from, to), nil
+}
@@ -0,0 +1,61 @@
+package eth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+func TestMilestoneVotes(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+
+	writeMilestoneVote(db, 30, 1, 10, "0x10", "id-10", true, nil)
+	writeMilestoneVote(db, 30, 11, 20, "0x20", "id-20", false, errors.New("Hash mismatch"))
+	writeMilestoneVote(db, 30, 11, 20, "0x20", "id-20", true, nil)
+	writeMilestoneVote(db, 30, 21, 30, "0x30", "id-30", false, errTipConfirmationBlock)
+
+	votes := readMilestoneVotes(db, 10, 20)
+	require.Len(t, votes, 3)
+
+	require.Equal(t, uint64(1), votes[0].StartBlock)
+	require.Equal(t, uint64(10), votes[0].EndBlock)
+	require.Equal(t, "0x10", votes[0].Hash)
+	require.Equal(t, "id-10", votes[0].MilestoneID)
+	require.True(t, votes[0].Vote)
+	require.Empty(t, votes[0].Reason)
+
+	// The votes on a milestone are ordered by time
+	require.False(t, votes[1].Vote)
+	require.Equal(t, "Hash mismatch", votes[1].Reason)
+	require.True(t, votes[2].Vote)
+	require.False(t, votes[2].Time.Before(votes[1].Time))
+
+	votes = readMilestoneVotes(db, 21, 100)
+	require.Len(t, votes, 1)
+	require.Equal(t, errTipConfirmationBlock.Error(), votes[0].Reason)
+
+	require.Empty(t, readMilestoneVotes(db, 31, 100))
+}
+
+func TestMilestoneVotesRetention(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+
+	writeMilestoneVote(db, 10, 1, 10, "0x10", "id-10", true, nil)
+	writeMilestoneVote(db, 20, 11, 20, "0x20", "id-20", true, nil)
+	require.Len(t, readMilestoneVotes(db, 0, 100), 2)
+
+	// The votes on the milestones ending before the retention window are pruned
+	writeMilestoneVote(db, milestoneVotesRetention+20, milestoneVotesRetention+11, milestoneVotesRetention+20, "0x30", "id-30", true, nil)
+
+	votes := readMilestoneVotes(db, 0, milestoneVotesRetention+20)
+	require.Len(t, votes, 2)
+	require.Equal(t, "id-20", votes[0].MilestoneID)
+	require.Equal(t, "id-30", votes[1].MilestoneID)
+}
@@ -152,6 +152,8 @@ type Downloader struct {
 
 	ethereum.ChainValidator
 
+	headerObserver func(...*types.Header) // Method to call with every batch of headers received from peers
+
 	// Testing hooks
 	syncInitHook     func(uint64, uint64)  // Method to call upon initiating a new sync run
 	bodyFetchHook    func([]*types.Header) // Method to call upon starting a block body fetch
@@ -896,6 +898,120 @@ func (d *Downloader) getFetchHeadersByNumber(p *peerConnection) func(number uint
 	}
 }
 
+// ValidatePeers checks the chain of every peer against the whitelisted blocks
+// with IsValidPeer and, if a hash is given, against the header of the block
+// number. The peers serving conflicting headers are dropped, the ids of the ones
+// serving the expected headers are returned with the number of dropped peers.
+// The peers which don't serve the headers are ignored.
+func (d *Downloader) ValidatePeers(number uint64, hash common.Hash) ([]string, int) {
+	var (
+		valid   []string
+		dropped int
+		lock    sync.Mutex
+		wg      sync.WaitGroup
+	)
+
+	for _, p := range d.peers.AllPeers() {
+		wg.Add(1)
+
+		go func(p *peerConnection) {
+			defer wg.Done()
+
+			err := d.validatePeer(p, number, hash)
+			if err != nil && !errors.Is(err, whitelist.ErrMismatch) {
+				p.log.Debug("Peer not validated", "number", number, "err", err)
+				return
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			if err == nil {
+				valid = append(valid, p.id)
+				return
+			}
+
+			p.log.Warn("Dropping peer serving a conflicting chain", "number", number, "err", err)
+
+			dropped++
+
+			if d.dropPeer != nil {
+				d.dropPeer(p.id)
+			}
+		}(p)
+	}
+
+	wg.Wait()
+
+	return valid, dropped
+}
+
+func (d *Downloader) validatePeer(p *peerConnection, number uint64, hash common.Hash) error {
+	fetchHeadersByNumber := d.getFetchHeadersByNumber(p)
+
+	if d.ChainValidator != nil {
+		if _, err := d.IsValidPeer(fetchHeadersByNumber); err != nil {
+			return err
+		}
+	}
+
+	if hash == (common.Hash{}) {
+		return nil
+	}
+
+	headers, hashes, err := fetchHeadersByNumber(number, 1, 0, false)
+	if err != nil {
+		return fmt.Errorf("%w: block number %d, err %v", whitelist.ErrNoRemote, number, err)
+	}
+
+	if len(headers) == 0 {
+		return fmt.Errorf("%w: block number %d", whitelist.ErrNoRemote, number)
+	}
+
+	if headers[0].Number.Uint64() != number || hashes[0] != hash {
+		return fmt.Errorf("%w: block number %d, hash %s", whitelist.ErrMismatch, number, hashes[0])
+	}
+
+	return nil
+}
+
+// FetchHeaders retrieves the consecutive headers in the range [from, to] from
+// the given peer, in batches of MaxHeaderFetch headers.
+func (d *Downloader) FetchHeaders(id string, from uint64, to uint64) ([]*types.Header, error) {
+	p := d.peers.Peer(id)
+	if p == nil {
+		return nil, errUnknownPeer
+	}
+
+	headers := make([]*types.Header, 0, to-from+1)
+
+	for number := from; number <= to; number += uint64(MaxHeaderFetch) {
+		amount := MaxHeaderFetch
+		if left := to - number + 1; left < uint64(amount) {
+			amount = int(left)
+		}
+
+		batch, _, err := d.fetchHeadersByNumber(p, number, amount, 0, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(batch) != amount {
+			return nil, fmt.Errorf("%w: requested %d headers from %d, got %d", errBadPeer, amount, number, len(batch))
+		}
+
+		headers = append(headers, batch...)
+	}
+
+	return headers, nil
+}
+
+// SkeletonHeader retrieves a header of the chain backfilled by a beacon sync,
+// nil if it isn't held by the skeleton, e.g. once imported in the local chain.
+func (d *Downloader) SkeletonHeader(number uint64) *types.Header {
+	return d.skeleton.Header(number)
+}
+
 // findAncestor tries to locate the common ancestor link of the local chain and
 // a remote peers blockchain. In the general case when our node was in sync and
 // on the correct chain, checking the top N links should already get us a match.
@@ -1326,6 +1442,10 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64, head uint64) e
 		}
 		// Insert any remaining new headers and fetch the next batch
 		if len(headers) > 0 {
+			if d.headerObserver != nil {
+				d.headerObserver(headers...)
+			}
+
 			p.log.Trace("Scheduling new headers", "count", len(headers), "from", from)
 			select {
 			case d.headerProcCh <- &headerTask{
@@ -1997,6 +2117,12 @@ func (d *Downloader) DeliverSnapPacket(peer *snap.Peer, packet snap.Packet) erro
 	}
 }
 
+// SetHeaderObserver sets a method called with the headers received from peers,
+// before they are processed. It must be set before synchronisation starts.
+func (d *Downloader) SetHeaderObserver(observer func(...*types.Header)) {
+	d.headerObserver = observer
+}
+
 // GetWhitelistService returns the pointer to the whitelist service
 func (d *Downloader) GetWhitelistService() ethereum.ChainValidator {
 	return d.ChainValidator
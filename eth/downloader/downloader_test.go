@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -1976,18 +1977,27 @@ func (w *whitelistFake) IsValidPeer(_ func(number uint64, amount int, skip int,
 func (w *whitelistFake) IsValidChain(current *types.Header, headers []*types.Header) (bool, error) {
 	return true, nil
 }
-func (w *whitelistFake) ProcessCheckpoint(_ uint64, _ common.Hash) {}
+func (w *whitelistFake) ProcessCheckpoint(_ uint64, _ common.Hash, _ uint64) {}
 
 func (w *whitelistFake) GetWhitelistedCheckpoint() (bool, uint64, common.Hash) {
 	return false, 0, common.Hash{}
 }
+func (w *whitelistFake) GetWhitelistedCheckpointNumber() uint64 {
+	return 0
+}
 func (w *whitelistFake) PurgeWhitelistedCheckpoint() {}
 
-func (w *whitelistFake) ProcessMilestone(_ uint64, _ common.Hash)       {}
-func (w *whitelistFake) ProcessFutureMilestone(_ uint64, _ common.Hash) {}
+func (w *whitelistFake) ProcessMilestone(_ uint64, _ common.Hash, _ string) {}
+func (w *whitelistFake) ProcessFutureMilestone(_ uint64, _ common.Hash)     {}
 func (w *whitelistFake) GetWhitelistedMilestone() (bool, uint64, common.Hash) {
 	return false, 0, common.Hash{}
 }
+func (w *whitelistFake) GetWhitelistedMilestoneID() string {
+	return ""
+}
+func (w *whitelistFake) SubscribeFinality(ch chan<- *types.FinalityBlock) event.Subscription {
+	return new(event.Feed).Subscribe(ch)
+}
 func (w *whitelistFake) PurgeWhitelistedMilestone() {}
 
 func (w *whitelistFake) GetCheckpoints(current, sidechainHeader *types.Header, sidechainCheckpoints []*types.Header) (map[uint64]*types.Header, error) {
@@ -2093,3 +2103,72 @@ func TestFakedSyncProgress66NoRemoteCheckpoint(t *testing.T) {
 		t.Fatal("succeeded attacker synchronisation")
 	}
 }
+
+// Tests that the peers serving headers conflicting with the whitelisted blocks,
+// or with the expected block, are dropped while validating the peers.
+func TestValidatePeers(t *testing.T) {
+	t.Parallel()
+
+	tester := newTester(t)
+	defer tester.terminate()
+
+	var (
+		base   = testChainBase.shorten(100)
+		chainA = testChainForkLightA.shorten(len(testChainBase.blocks) + 80)
+		chainB = testChainForkHeavy.shorten(len(testChainBase.blocks) + 79)
+		number = uint64(len(testChainBase.blocks) + 10)
+	)
+
+	tester.newPeer("fork A", eth.ETH67, chainA.blocks[1:])
+	tester.newPeer("fork B", eth.ETH67, chainB.blocks[1:])
+	tester.newPeer("short", eth.ETH67, base.blocks[1:])
+
+	// Without an expected hash, the chains are only checked against the whitelisted blocks
+	tester.downloader.ChainValidator.ProcessMilestone(50, base.blocks[50].Hash(), "")
+
+	valid, dropped := tester.downloader.ValidatePeers(number, common.Hash{})
+	sort.Strings(valid)
+	assert.Equal(t, []string{"fork A", "fork B", "short"}, valid)
+	assert.Equal(t, 0, dropped)
+
+	// The peer without the expected block is ignored, the conflicting one is dropped
+	valid, dropped = tester.downloader.ValidatePeers(number, chainA.blocks[number].Hash())
+	assert.Equal(t, []string{"fork A"}, valid)
+	assert.Equal(t, 1, dropped)
+	assert.Nil(t, tester.downloader.peers.Peer("fork B"))
+	assert.NotNil(t, tester.downloader.peers.Peer("short"))
+
+	// The peers conflicting with the whitelisted blocks are dropped
+	tester.downloader.ChainValidator.ProcessMilestone(50, common.Hash{0x1}, "")
+
+	valid, dropped = tester.downloader.ValidatePeers(number, chainA.blocks[number].Hash())
+	assert.Empty(t, valid)
+	assert.Equal(t, 2, dropped)
+	assert.Zero(t, tester.downloader.peers.Len())
+}
+
+// Tests that a range of headers longer than a single request is fetched from a peer.
+func TestFetchHeaders(t *testing.T) {
+	t.Parallel()
+
+	tester := newTester(t)
+	defer tester.terminate()
+
+	chain := testChainBase.shorten(800)
+	tester.newPeer("peer", eth.ETH67, chain.blocks[1:])
+
+	headers, err := tester.downloader.FetchHeaders("peer", 10, uint64(10+2*MaxHeaderFetch))
+	assert.NoError(t, err)
+	assert.Len(t, headers, 2*MaxHeaderFetch+1)
+
+	for i, header := range headers {
+		assert.Equal(t, chain.blocks[10+i].Hash(), header.Hash())
+	}
+
+	// The peer doesn't have the blocks
+	_, err = tester.downloader.FetchHeaders("peer", 790, 810)
+	assert.Error(t, err)
+
+	_, err = tester.downloader.FetchHeaders("unknown", 10, 20)
+	assert.ErrorIs(t, err, errUnknownPeer)
+}
@@ -57,11 +57,11 @@ func (w *checkpoint) IsValidPeer(fetchHeadersByNumber func(number uint64, amount
 	return res, err
 }
 
-func (w *checkpoint) Process(block uint64, hash common.Hash) {
+func (w *checkpoint) Process(block uint64, hash common.Hash, id string) {
 	w.finality.Lock()
 	defer w.finality.Unlock()
 
-	w.finality.Process(block, hash)
+	w.finality.Process(block, hash, id)
 
 	whitelistedCheckpointNumberMeter.Update(int64(block))
 }
@@ -16,6 +16,7 @@ type finality[T rawdb.BlockFinality[T]] struct {
 	db       ethdb.Database
 	Hash     common.Hash // Whitelisted Hash, populated by reaching out to heimdall
 	Number   uint64      // Number , populated by reaching out to heimdall
+	ID       string      // Heimdall id of the whitelisted entry, populated by reaching out to heimdall
 	interval uint64      // Interval, until which we can allow importing
 	doExist  bool
 }
@@ -24,7 +25,8 @@ type finalityService interface {
 	IsValidPeer(fetchHeadersByNumber func(number uint64, amount int, skip int, reverse bool) ([]*types.Header, []common.Hash, error)) (bool, error)
 	IsValidChain(currentHeader *types.Header, chain []*types.Header) (bool, error)
 	Get() (bool, uint64, common.Hash)
-	Process(block uint64, hash common.Hash)
+	GetID() string
+	Process(block uint64, hash common.Hash, id string)
 	Purge()
 }
 
@@ -57,12 +59,13 @@ func (f *finality[T]) IsValidChain(currentHeader *types.Header, chain []*types.H
 	return res, err
 }
 
-func (f *finality[T]) Process(block uint64, hash common.Hash) {
+func (f *finality[T]) Process(block uint64, hash common.Hash, id string) {
 	f.doExist = true
 	f.Hash = hash
 	f.Number = block
+	f.ID = id
 
-	err := rawdb.WriteLastFinality[T](f.db, block, hash)
+	err := rawdb.WriteLastFinality[T](f.db, block, hash, id)
 	if err != nil {
 		log.Error("Error in writing whitelist state to db", "err", err)
 	}
@@ -87,6 +90,14 @@ func (f *finality[T]) Get() (bool, uint64, common.Hash) {
 	return true, block, hash
 }
 
+// GetID returns the heimdall id of the whitelisted entry, empty if unknown.
+func (f *finality[T]) GetID() string {
+	f.RLock()
+	defer f.RUnlock()
+
+	return f.ID
+}
+
 // Purge purges the whitlisted checkpoint
 func (f *finality[T]) Purge() {
 	f.Lock()
@@ -111,11 +111,11 @@ func (m *milestone) IsValidPeer(fetchHeadersByNumber func(number uint64, amount
 	return res, err
 }
 
-func (m *milestone) Process(block uint64, hash common.Hash) {
+func (m *milestone) Process(block uint64, hash common.Hash, id string) {
 	m.finality.Lock()
 	defer m.finality.Unlock()
 
-	m.finality.Process(block, hash)
+	m.finality.Process(block, hash, id)
 
 	for i := 0; i < len(m.FutureMilestoneOrder); i++ {
 		if m.FutureMilestoneOrder[i] <= block {
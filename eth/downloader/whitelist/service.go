@@ -3,11 +3,13 @@ package whitelist
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
 )
 
 var (
@@ -22,6 +24,8 @@ var (
 type Service struct {
 	checkpointService
 	milestoneService
+
+	finalityFeed event.Feed // Feed of the whitelisted entries advancing the finality
 }
 
 func NewService(db ethdb.Database) *Service {
@@ -33,6 +37,8 @@ func NewService(db ethdb.Database) *Service {
 		checkpointDoExist = false
 	}
 
+	checkpointID, _ := rawdb.ReadFinalityID[*rawdb.Checkpoint](db)
+
 	var milestoneDoExist = true
 
 	milestoneNumber, milestoneHash, err := rawdb.ReadFinality[*rawdb.Milestone](db)
@@ -40,6 +46,8 @@ func NewService(db ethdb.Database) *Service {
 		milestoneDoExist = false
 	}
 
+	milestoneID, _ := rawdb.ReadFinalityID[*rawdb.Milestone](db)
+
 	locked, lockedMilestoneNumber, lockedMilestoneHash, lockedMilestoneIDs, err := rawdb.ReadLockField(db)
 	if err != nil || !locked {
 		locked = false
@@ -53,21 +61,23 @@ func NewService(db ethdb.Database) *Service {
 	}
 
 	return &Service{
-		&checkpoint{
+		checkpointService: &checkpoint{
 			finality[*rawdb.Checkpoint]{
 				doExist:  checkpointDoExist,
 				Number:   checkpointNumber,
 				Hash:     checkpointHash,
+				ID:       checkpointID,
 				interval: 256,
 				db:       db,
 			},
 		},
 
-		&milestone{
+		milestoneService: &milestone{
 			finality: finality[*rawdb.Milestone]{
 				doExist:  milestoneDoExist,
 				Number:   milestoneNumber,
 				Hash:     milestoneHash,
+				ID:       milestoneID,
 				interval: 256,
 				db:       db,
 			},
@@ -115,12 +125,62 @@ func (s *Service) GetWhitelistedMilestone() (bool, uint64, common.Hash) {
 	return s.milestoneService.Get()
 }
 
-func (s *Service) ProcessMilestone(endBlockNum uint64, endBlockHash common.Hash) {
-	s.milestoneService.Process(endBlockNum, endBlockHash)
+// GetWhitelistedCheckpointNumber returns the heimdall number of the whitelisted
+// checkpoint, 0 if unknown.
+func (s *Service) GetWhitelistedCheckpointNumber() uint64 {
+	number, err := strconv.ParseUint(s.checkpointService.GetID(), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return number
+}
+
+// GetWhitelistedMilestoneID returns the heimdall id of the whitelisted milestone,
+// empty if unknown.
+func (s *Service) GetWhitelistedMilestoneID() string {
+	return s.milestoneService.GetID()
+}
+
+// SubscribeFinality registers a subscription for the whitelisted milestones and
+// checkpoints which advance the finality of the chain.
+func (s *Service) SubscribeFinality(ch chan<- *types.FinalityBlock) event.Subscription {
+	return s.finalityFeed.Subscribe(ch)
 }
 
-func (s *Service) ProcessCheckpoint(endBlockNum uint64, endBlockHash common.Hash) {
-	s.checkpointService.Process(endBlockNum, endBlockHash)
+func (s *Service) ProcessMilestone(endBlockNum uint64, endBlockHash common.Hash, milestoneID string) {
+	doExist, number, _ := s.milestoneService.Get()
+
+	s.milestoneService.Process(endBlockNum, endBlockHash, milestoneID)
+
+	if !doExist || endBlockNum > number {
+		s.finalityFeed.Send(&types.FinalityBlock{
+			Number:      endBlockNum,
+			Hash:        endBlockHash,
+			Source:      types.FinalityMilestone,
+			MilestoneID: milestoneID,
+		})
+	}
+}
+
+func (s *Service) ProcessCheckpoint(endBlockNum uint64, endBlockHash common.Hash, checkpointNumber uint64) {
+	var id string
+	if checkpointNumber != 0 {
+		id = strconv.FormatUint(checkpointNumber, 10)
+	}
+
+	doExist, number, _ := s.checkpointService.Get()
+
+	s.checkpointService.Process(endBlockNum, endBlockHash, id)
+
+	if !doExist || endBlockNum > number {
+		s.finalityFeed.Send(&types.FinalityBlock{
+			Number:           endBlockNum,
+			Hash:             endBlockHash,
+			Source:           types.FinalityCheckpoint,
+			CheckpointNumber: checkpointNumber,
+		})
+	}
 }
 
 func (s *Service) IsValidChain(currentHeader *types.Header, chain []*types.Header) (bool, error) {
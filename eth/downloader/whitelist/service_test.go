@@ -23,8 +23,7 @@ import (
 // NewMockService creates a new mock whitelist service
 func NewMockService(db ethdb.Database) *Service {
 	return &Service{
-
-		&checkpoint{
+		checkpointService: &checkpoint{
 			finality[*rawdb.Checkpoint]{
 				doExist:  false,
 				interval: 256,
@@ -32,7 +31,7 @@ func NewMockService(db ethdb.Database) *Service {
 			},
 		},
 
-		&milestone{
+		milestoneService: &milestone{
 			finality: finality[*rawdb.Milestone]{
 				doExist:  false,
 				interval: 256,
@@ -63,7 +62,7 @@ func TestWhitelistedCheckpoint(t *testing.T) {
 	require.NotNil(t, err, "Error should be nil while reading from the db")
 
 	//Adding the checkpoint
-	s.ProcessCheckpoint(11, common.Hash{})
+	s.ProcessCheckpoint(11, common.Hash{}, 0)
 
 	require.Equal(t, cp.doExist, true, "expected true as cp exist")
 
@@ -73,7 +72,7 @@ func TestWhitelistedCheckpoint(t *testing.T) {
 	require.Equal(t, cp.doExist, false, "expected false as no cp exist at this point")
 
 	//Adding the checkpoint
-	s.ProcessCheckpoint(12, common.Hash{1})
+	s.ProcessCheckpoint(12, common.Hash{1}, 0)
 
 	//Receiving the stored checkpoint
 	doExist, number, hash := s.GetWhitelistedCheckpoint()
@@ -102,6 +101,58 @@ func TestWhitelistedCheckpoint(t *testing.T) {
 	require.Equal(t, checkpointNumber, uint64(12), "expected number to be 11 but got", number)
 }
 
+// TestWhitelistedIDs checks that the heimdall ids of the whitelisted checkpoint
+// and milestone are kept across restarts.
+func TestWhitelistedIDs(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	s := NewService(db)
+
+	require.Equal(t, uint64(0), s.GetWhitelistedCheckpointNumber())
+	require.Equal(t, "", s.GetWhitelistedMilestoneID())
+
+	s.ProcessCheckpoint(255, common.Hash{1}, 7)
+	s.ProcessMilestone(300, common.Hash{2}, "milestone-1")
+
+	require.Equal(t, uint64(7), s.GetWhitelistedCheckpointNumber())
+	require.Equal(t, "milestone-1", s.GetWhitelistedMilestoneID())
+
+	s = NewService(db)
+
+	require.Equal(t, uint64(7), s.GetWhitelistedCheckpointNumber())
+	require.Equal(t, "milestone-1", s.GetWhitelistedMilestoneID())
+
+	// An unknown checkpoint number isn't kept
+	s.ProcessCheckpoint(511, common.Hash{3}, 0)
+
+	require.Equal(t, uint64(0), s.GetWhitelistedCheckpointNumber())
+}
+
+// TestSubscribeFinality checks that the whitelisted entries are only sent to the
+// subscribers when they advance the finality.
+func TestSubscribeFinality(t *testing.T) {
+	t.Parallel()
+
+	s := NewService(rawdb.NewMemoryDatabase())
+
+	blocks := make(chan *types.FinalityBlock, 8)
+	sub := s.SubscribeFinality(blocks)
+
+	defer sub.Unsubscribe()
+
+	s.ProcessMilestone(16, common.Hash{1}, "milestone-1")
+	s.ProcessMilestone(16, common.Hash{1}, "milestone-1")
+	s.ProcessCheckpoint(8, common.Hash{2}, 1)
+	s.ProcessMilestone(32, common.Hash{3}, "milestone-2")
+	s.ProcessCheckpoint(8, common.Hash{2}, 1)
+
+	require.Equal(t, &types.FinalityBlock{Number: 16, Hash: common.Hash{1}, Source: types.FinalityMilestone, MilestoneID: "milestone-1"}, <-blocks)
+	require.Equal(t, &types.FinalityBlock{Number: 8, Hash: common.Hash{2}, Source: types.FinalityCheckpoint, CheckpointNumber: 1}, <-blocks)
+	require.Equal(t, &types.FinalityBlock{Number: 32, Hash: common.Hash{3}, Source: types.FinalityMilestone, MilestoneID: "milestone-2"}, <-blocks)
+	require.Empty(t, blocks)
+}
+
 // TestMilestone checks the milestone whitelist setter and getter functions
 func TestMilestone(t *testing.T) {
 	t.Parallel()
@@ -163,7 +214,7 @@ func TestMilestone(t *testing.T) {
 	require.Equal(t, len(milestone.LockedMilestoneIDs), 1, "expected 1 as previous milestonesIDs has been removed in previous step")
 
 	//Adding the milestone
-	s.ProcessMilestone(11, common.Hash{})
+	s.ProcessMilestone(11, common.Hash{}, "")
 
 	require.True(t, milestone.Locked, "expected true as locked sprint is of number 15")
 	require.Equal(t, milestone.doExist, true, "expected true as milestone exist")
@@ -186,7 +237,7 @@ func TestMilestone(t *testing.T) {
 	milestone.UnlockMutex(false, "", uint64(11), common.Hash{}) //Unlock is required after every lock to release the mutex
 
 	//Adding the milestone
-	s.ProcessMilestone(51, common.Hash{})
+	s.ProcessMilestone(51, common.Hash{}, "")
 	require.False(t, milestone.Locked, "expected false as lock from sprint number 15 is removed")
 	require.Equal(t, milestone.doExist, true, "expected true as milestone exist")
 	require.Equal(t, len(milestone.LockedMilestoneIDs), 0, "expected 0 as all the milestones have been removed")
@@ -204,7 +255,7 @@ func TestMilestone(t *testing.T) {
 	require.Equal(t, milestone.doExist, false, "expected false as no milestone exist at this point")
 
 	//Removing the milestone
-	s.ProcessMilestone(11, common.Hash{1})
+	s.ProcessMilestone(11, common.Hash{1}, "")
 
 	doExist, number, hash := s.GetWhitelistedMilestone()
 
@@ -263,10 +314,10 @@ func TestIsValidPeer(t *testing.T) {
 	require.Equal(t, res, true, "expected chain to be valid")
 
 	// add checkpoint entry and mock fetchHeadersByNumber function
-	s.ProcessCheckpoint(uint64(1), common.Hash{})
+	s.ProcessCheckpoint(uint64(1), common.Hash{}, 0)
 
 	// add milestone entry and mock fetchHeadersByNumber function
-	s.ProcessMilestone(uint64(1), common.Hash{})
+	s.ProcessMilestone(uint64(1), common.Hash{}, "")
 
 	checkpoint := s.checkpointService.(*checkpoint)
 	milestone := s.milestoneService.(*milestone)
@@ -318,7 +369,7 @@ func TestIsValidPeer(t *testing.T) {
 	require.Equal(t, res, true, "expected chain to be valid")
 
 	// add checkpoint whitelist entry
-	s.ProcessCheckpoint(uint64(2), common.Hash{})
+	s.ProcessCheckpoint(uint64(2), common.Hash{}, 0)
 	require.Equal(t, checkpoint.doExist, true, "expected true as checkpoint exists")
 
 	// case4: correct fetchHeadersByNumber function provided with wrong header
@@ -353,14 +404,14 @@ func TestIsValidPeer(t *testing.T) {
 		}
 	}
 
-	s.ProcessMilestone(uint64(3), common.Hash{})
+	s.ProcessMilestone(uint64(3), common.Hash{}, "")
 
 	//Case5: correct fetchHeadersByNumber function provided with hash mismatch, should consider the chain as invalid
 	res, err = s.IsValidPeer(fetchHeadersByNumber)
 	require.Equal(t, err, ErrMismatch, "expected milestone mismatch error")
 	require.Equal(t, res, false, "expected chain to be invalid")
 
-	s.ProcessMilestone(uint64(2), common.Hash{})
+	s.ProcessMilestone(uint64(2), common.Hash{}, "")
 
 	// create a mock function, returning the required header
 	fetchHeadersByNumber = func(number uint64, _ int, _ int, _ bool) ([]*types.Header, []common.Hash, error) {
@@ -415,7 +466,7 @@ func TestIsValidPeer(t *testing.T) {
 	}
 
 	//Add one more milestone in the list
-	s.ProcessMilestone(uint64(3), common.Hash{})
+	s.ProcessMilestone(uint64(3), common.Hash{}, "")
 
 	// case7: correct fetchHeadersByNumber function provided with wrong header for block 3, should consider the chain as invalid
 	res, err = s.IsValidPeer(fetchHeadersByNumber)
@@ -425,7 +476,7 @@ func TestIsValidPeer(t *testing.T) {
 	//require.Equal(t, milestone.length(), 3, "expected 3 items in milestoneList")
 
 	//Add one more milestone in the list
-	s.ProcessMilestone(uint64(4), common.Hash{})
+	s.ProcessMilestone(uint64(4), common.Hash{}, "")
 
 	// case8: correct fetchHeadersByNumber function provided with wrong hash for block 3, should consider the chain as valid
 	res, err = s.IsValidPeer(fetchHeadersByNumber)
@@ -450,7 +501,7 @@ func TestIsValidChain(t *testing.T) {
 	tempChain := createMockChain(21, 22) // A21->A22
 
 	// add mock checkpoint entry
-	s.ProcessCheckpoint(tempChain[1].Number.Uint64(), tempChain[1].Hash())
+	s.ProcessCheckpoint(tempChain[1].Number.Uint64(), tempChain[1].Hash(), 0)
 
 	//Make the mock chain with zero blocks
 	zeroChain := make([]*types.Header, 0)
@@ -473,7 +524,7 @@ func TestIsValidChain(t *testing.T) {
 	require.Equal(t, res, false, "expected chain to be invalid ")
 
 	// add mock milestone entry
-	s.ProcessMilestone(tempChain[1].Number.Uint64(), tempChain[1].Hash())
+	s.ProcessMilestone(tempChain[1].Number.Uint64(), tempChain[1].Hash(), "")
 
 	//Case4A: As the received chain and current tip of local chain is behind the oldest whitelisted block entry, should consider
 	// the chain as valid
@@ -546,7 +597,7 @@ func TestIsValidChain(t *testing.T) {
 
 	// Clear checkpoint whitelist and add block A15 in whitelist
 	s.PurgeWhitelistedCheckpoint()
-	s.ProcessCheckpoint(chainA[15].Number.Uint64(), chainA[15].Hash())
+	s.ProcessCheckpoint(chainA[15].Number.Uint64(), chainA[15].Hash(), 0)
 
 	require.Equal(t, checkpoint.doExist, true, "expected true as checkpoint exists.")
 
@@ -556,7 +607,7 @@ func TestIsValidChain(t *testing.T) {
 	require.Equal(t, res, true, "expected chain to be valid")
 
 	// add mock milestone entries
-	s.ProcessMilestone(tempChain[1].Number.Uint64(), tempChain[1].Hash())
+	s.ProcessMilestone(tempChain[1].Number.Uint64(), tempChain[1].Hash(), "")
 
 	// case10: Try importing a past chain having valid checkpoint, should
 	// consider the chain as invalid as still lastest milestone is ahead of the chain.
@@ -565,7 +616,7 @@ func TestIsValidChain(t *testing.T) {
 	require.Equal(t, res, false, "expected chain to be invalid")
 
 	// add mock milestone entries
-	s.ProcessMilestone(chainA[19].Number.Uint64(), chainA[19].Hash())
+	s.ProcessMilestone(chainA[19].Number.Uint64(), chainA[19].Hash(), "")
 
 	// case12: Try importing a chain having valid checkpoint and milestone, should
 	// consider the chain as valid
@@ -574,7 +625,7 @@ func TestIsValidChain(t *testing.T) {
 	require.Equal(t, res, true, "expected chain to be invalid")
 
 	// add mock milestone entries
-	s.ProcessMilestone(chainA[19].Number.Uint64(), chainA[19].Hash())
+	s.ProcessMilestone(chainA[19].Number.Uint64(), chainA[19].Hash(), "")
 
 	// case13: Try importing a past chain having valid checkpoint and milestone, should
 	// consider the chain as valid
@@ -583,7 +634,7 @@ func TestIsValidChain(t *testing.T) {
 	require.Equal(t, res, true, "expected chain to be valid")
 
 	// add mock milestone entries with wrong hash
-	s.ProcessMilestone(chainA[19].Number.Uint64(), chainA[18].Hash())
+	s.ProcessMilestone(chainA[19].Number.Uint64(), chainA[18].Hash(), "")
 
 	// case14: Try importing a past chain having valid checkpoint and milestone with wrong hash, should
 	// consider the chain as invalid
@@ -592,7 +643,7 @@ func TestIsValidChain(t *testing.T) {
 	require.Equal(t, res, false, "expected chain to be invalid as hash mismatches")
 
 	// Clear milestone and add blocks A15 in whitelist
-	s.ProcessMilestone(chainA[15].Number.Uint64(), chainA[15].Hash())
+	s.ProcessMilestone(chainA[15].Number.Uint64(), chainA[15].Hash(), "")
 
 	// case16: Try importing a past chain having valid checkpoint, should
 	// consider the chain as valid
@@ -604,7 +655,7 @@ func TestIsValidChain(t *testing.T) {
 	tempChain = createMockChain(20, 20) // A20
 
 	s.PurgeWhitelistedCheckpoint()
-	s.ProcessCheckpoint(tempChain[0].Number.Uint64(), tempChain[0].Hash())
+	s.ProcessCheckpoint(tempChain[0].Number.Uint64(), tempChain[0].Hash(), 0)
 
 	require.Equal(t, checkpoint.doExist, true, "expected true")
 
@@ -867,7 +918,7 @@ func TestPropertyBasedTestingMilestone(t *testing.T) {
 
 		lockedValue := milestone.LockedMilestoneNumber
 
-		milestone.Process(milestoneNum.(uint64), common.Hash{})
+		milestone.Process(milestoneNum.(uint64), common.Hash{}, "")
 
 		isChainLocked := doLock.(bool) || doLock2.(bool)
 
@@ -30,10 +30,12 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/consensus/bor/contract"
-	"github.com/ethereum/go-ethereum/consensus/bor/heimdall" //nolint:typecheck
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdallapp"
-	"github.com/ethereum/go-ethereum/consensus/bor/heimdallgrpc"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallcache"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallmux"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallsim"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallverify"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
@@ -99,6 +101,7 @@ var Defaults = Config{
 	RPCEVMTimeout:           5 * time.Second,
 	GPO:                     FullNodeGPO,
 	RPCTxFeeCap:             5, // 1 ether
+	RPCSafeBlock:            SafeBlockMilestone,
 }
 
 func init() {
@@ -123,6 +126,12 @@ func init() {
 	}
 }
 
+// Policies the 'safe' block tag is resolved with on bor.
+const (
+	SafeBlockMilestone  = "milestone"  // The last whitelisted milestone, or the block with enough confirmations
+	SafeBlockCheckpoint = "checkpoint" // The last whitelisted checkpoint
+)
+
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
 
 // Config contains configuration options for of the ETH and LES protocols.
@@ -197,6 +206,9 @@ type Config struct {
 	// Transaction pool options
 	TxPool txpool.Config
 
+	// Relay the conditional transactions to the trusted peers over the condtx protocol
+	ConditionalTxRelay bool
+
 	// Gas Price Oracle options
 	GPO gasprice.Config
 
@@ -219,6 +231,13 @@ type Config struct {
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// RPCSafeBlock is the policy the 'safe' block tag is resolved with on bor.
+	RPCSafeBlock string
+
+	// RPCSafeBlockConfirmations is the number of confirmations after which a block
+	// is safe with the milestone policy, even if no milestone covers it yet (0 = disabled).
+	RPCSafeBlockConfirmations uint64
+
 	// Checkpoint is a hardcoded checkpoint which can be nil.
 	Checkpoint *params.TrustedCheckpoint `toml:",omitempty"`
 
@@ -246,9 +265,43 @@ type Config struct {
 	// Use child heimdall process to fetch data, Only works when RunHeimdall is true
 	UseHeimdallApp bool
 
+	// Path to the config of an in-process heimdall simulator, replaces the remote heimdall
+	HeimdallSimulator string
+
+	// Persist spans, state-sync events and checkpoints fetched from heimdall in the database
+	HeimdallCache bool
+
+	// Additional heimdall REST endpoints to fail over to
+	HeimdallEndpoints []string
+
+	// Additional heimdall gRPC endpoints to fail over to
+	HeimdallgRPCEndpoints []string
+
+	// Confirm span and milestone responses with a second heimdall endpoint
+	HeimdallCrossCheck bool
+
+	// Verify spans, checkpoints and milestones with proofs from heimdall's tendermint node
+	HeimdallVerify bool
+
+	// URL of the tendermint RPC of the heimdall node, used to verify heimdall responses
+	HeimdallTendermintURL string
+
+	// Height and hash of the heimdall header trusted on the first start of the verification
+	HeimdallTrustedHeight uint64
+	HeimdallTrustedHash   string
+
+	// Sync a new node from the latest heimdall checkpoint rather than from genesis
+	HeimdallBootstrap bool
+
+	// Number of checkpoints preceding the bootstrap checkpoint verified before the legacy sync is enabled, 0 for all (the older ones are verified afterwards)
+	HeimdallBootstrapVerify uint64
+
 	// Bor logs flag
 	BorLogs bool
 
+	// Track the block production of the validators
+	BorLiveness bool
+
 	// Parallel EVM (Block-STM) related config
 	ParallelEVM core.ParallelEVMConfig `toml:",omitempty"`
 
@@ -278,12 +331,44 @@ func CreateConsensusEngine(stack *node.Node, chainConfig *params.ChainConfig, et
 			}
 
 			var heimdallClient bor.IHeimdallClient
-			if ethConfig.RunHeimdall && ethConfig.UseHeimdallApp {
+			if ethConfig.HeimdallSimulator != "" {
+				simConfig, err := heimdallsim.LoadConfig(ethConfig.HeimdallSimulator)
+				if err != nil {
+					log.Crit("Failed to load Heimdall simulator config", "error", err)
+				}
+
+				heimdallClient, err = heimdallsim.NewSimulator(simConfig)
+				if err != nil {
+					log.Crit("Failed to start Heimdall simulator", "error", err)
+				}
+			} else if ethConfig.RunHeimdall && ethConfig.UseHeimdallApp {
 				heimdallClient = heimdallapp.NewHeimdallAppClient()
-			} else if ethConfig.HeimdallgRPCAddress != "" {
-				heimdallClient = heimdallgrpc.NewHeimdallGRPCClient(ethConfig.HeimdallgRPCAddress)
 			} else {
-				heimdallClient = heimdall.NewHeimdallClient(ethConfig.HeimdallURL)
+				heimdallClient = newHeimdallRemoteClient(ethConfig)
+
+				if ethConfig.HeimdallVerify {
+					var trustDir string
+					if stack != nil {
+						trustDir = stack.ResolvePath("heimdalltrust")
+					}
+
+					trust := heimdallverify.TrustOptions{
+						Height: int64(ethConfig.HeimdallTrustedHeight),
+						Hash:   common.FromHex(ethConfig.HeimdallTrustedHash),
+					}
+
+					verifyClient, err := heimdallverify.NewHeimdallVerifyClient(heimdallClient, ethConfig.HeimdallTendermintURL, trustDir, trust)
+					if err != nil {
+						log.Crit("Failed to set up Heimdall verification", "error", err)
+					}
+
+					heimdallClient = verifyClient
+				}
+			}
+
+			// The simulator is local and deterministic, there is nothing to cache
+			if ethConfig.HeimdallCache && ethConfig.HeimdallSimulator == "" {
+				heimdallClient = heimdallcache.NewHeimdallCacheClient(heimdallClient, db)
 			}
 
 			return bor.New(chainConfig, db, blockchainAPI, spanner, heimdallClient, genesisContractsClient, false)
@@ -316,3 +401,34 @@ func CreateConsensusEngine(stack *node.Node, chainConfig *params.ChainConfig, et
 
 	return beacon.New(engine)
 }
+
+// newHeimdallRemoteClient connects to the configured heimdall endpoint, failing
+// over to the additional endpoints when any are configured.
+func newHeimdallRemoteClient(ethConfig *Config) bor.IHeimdallClient {
+	endpoints := make([]heimdallmux.Endpoint, 0, 1+len(ethConfig.HeimdallgRPCEndpoints)+len(ethConfig.HeimdallEndpoints))
+
+	if ethConfig.HeimdallgRPCAddress != "" {
+		endpoints = append(endpoints, heimdallmux.NewGRPCEndpoint(ethConfig.HeimdallgRPCAddress))
+	} else {
+		endpoints = append(endpoints, heimdallmux.NewRESTEndpoint(ethConfig.HeimdallURL))
+	}
+
+	for _, address := range ethConfig.HeimdallgRPCEndpoints {
+		endpoints = append(endpoints, heimdallmux.NewGRPCEndpoint(address))
+	}
+
+	for _, url := range ethConfig.HeimdallEndpoints {
+		endpoints = append(endpoints, heimdallmux.NewRESTEndpoint(url))
+	}
+
+	if len(endpoints) == 1 {
+		return endpoints[0].Client
+	}
+
+	heimdallClient, err := heimdallmux.NewHeimdallMuxClient(endpoints, 0, ethConfig.HeimdallCrossCheck)
+	if err != nil {
+		log.Crit("Failed to create Heimdall failover client", "error", err)
+	}
+
+	return heimdallClient
+}
@@ -58,6 +58,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Miner                                miner.Config
 		Ethash                               ethash.Config
 		TxPool                               txpool.Config
+		ConditionalTxRelay                   bool
 		GPO                                  gasprice.Config
 		EnablePreimageRecording              bool
 		DocRoot                              string `toml:"-"`
@@ -65,6 +66,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RPCReturnDataLimit                   uint64
 		RPCEVMTimeout                        time.Duration
 		RPCTxFeeCap                          float64
+		RPCSafeBlock                         string
+		RPCSafeBlockConfirmations            uint64
 		Checkpoint                           *params.TrustedCheckpoint      `toml:",omitempty"`
 		CheckpointOracle                     *params.CheckpointOracleConfig `toml:",omitempty"`
 		OverrideShanghai                     *big.Int                       `toml:",omitempty"`
@@ -74,7 +77,19 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RunHeimdall                          bool
 		RunHeimdallArgs                      string
 		UseHeimdallApp                       bool
+		HeimdallSimulator                    string
+		HeimdallCache                        bool
+		HeimdallEndpoints                    []string
+		HeimdallgRPCEndpoints                []string
+		HeimdallCrossCheck                   bool
+		HeimdallVerify                       bool
+		HeimdallTendermintURL                string
+		HeimdallTrustedHeight                uint64
+		HeimdallTrustedHash                  string
+		HeimdallBootstrap                    bool
+		HeimdallBootstrapVerify              uint64
 		BorLogs                              bool
+		BorLiveness                          bool
 		ParallelEVM                          core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
 	}
@@ -118,6 +133,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.Ethash = c.Ethash
 	enc.TxPool = c.TxPool
+	enc.ConditionalTxRelay = c.ConditionalTxRelay
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.DocRoot = c.DocRoot
@@ -125,6 +141,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RPCReturnDataLimit = c.RPCReturnDataLimit
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.RPCSafeBlock = c.RPCSafeBlock
+	enc.RPCSafeBlockConfirmations = c.RPCSafeBlockConfirmations
 	enc.Checkpoint = c.Checkpoint
 	enc.CheckpointOracle = c.CheckpointOracle
 	enc.OverrideShanghai = c.OverrideShanghai
@@ -134,7 +152,19 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RunHeimdall = c.RunHeimdall
 	enc.RunHeimdallArgs = c.RunHeimdallArgs
 	enc.UseHeimdallApp = c.UseHeimdallApp
+	enc.HeimdallSimulator = c.HeimdallSimulator
+	enc.HeimdallCache = c.HeimdallCache
+	enc.HeimdallEndpoints = c.HeimdallEndpoints
+	enc.HeimdallgRPCEndpoints = c.HeimdallgRPCEndpoints
+	enc.HeimdallCrossCheck = c.HeimdallCrossCheck
+	enc.HeimdallVerify = c.HeimdallVerify
+	enc.HeimdallTendermintURL = c.HeimdallTendermintURL
+	enc.HeimdallTrustedHeight = c.HeimdallTrustedHeight
+	enc.HeimdallTrustedHash = c.HeimdallTrustedHash
+	enc.HeimdallBootstrap = c.HeimdallBootstrap
+	enc.HeimdallBootstrapVerify = c.HeimdallBootstrapVerify
 	enc.BorLogs = c.BorLogs
+	enc.BorLiveness = c.BorLiveness
 	enc.ParallelEVM = c.ParallelEVM
 	enc.DevFakeAuthor = c.DevFakeAuthor
 	return &enc, nil
@@ -182,6 +212,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Miner                                *miner.Config
 		Ethash                               *ethash.Config
 		TxPool                               *txpool.Config
+		ConditionalTxRelay                   *bool
 		GPO                                  *gasprice.Config
 		EnablePreimageRecording              *bool
 		DocRoot                              *string `toml:"-"`
@@ -189,6 +220,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RPCReturnDataLimit                   *uint64
 		RPCEVMTimeout                        *time.Duration
 		RPCTxFeeCap                          *float64
+		RPCSafeBlock                         *string
+		RPCSafeBlockConfirmations            *uint64
 		Checkpoint                           *params.TrustedCheckpoint      `toml:",omitempty"`
 		CheckpointOracle                     *params.CheckpointOracleConfig `toml:",omitempty"`
 		OverrideShanghai                     *big.Int                       `toml:",omitempty"`
@@ -198,7 +231,19 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RunHeimdall                          *bool
 		RunHeimdallArgs                      *string
 		UseHeimdallApp                       *bool
+		HeimdallSimulator                    *string
+		HeimdallCache                        *bool
+		HeimdallEndpoints                    []string
+		HeimdallgRPCEndpoints                []string
+		HeimdallCrossCheck                   *bool
+		HeimdallVerify                       *bool
+		HeimdallTendermintURL                *string
+		HeimdallTrustedHeight                *uint64
+		HeimdallTrustedHash                  *string
+		HeimdallBootstrap                    *bool
+		HeimdallBootstrapVerify              *uint64
 		BorLogs                              *bool
+		BorLiveness                          *bool
 		ParallelEVM                          *core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        *bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
 	}
@@ -323,6 +368,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TxPool != nil {
 		c.TxPool = *dec.TxPool
 	}
+	if dec.ConditionalTxRelay != nil {
+		c.ConditionalTxRelay = *dec.ConditionalTxRelay
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
@@ -344,6 +392,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.RPCSafeBlock != nil {
+		c.RPCSafeBlock = *dec.RPCSafeBlock
+	}
+	if dec.RPCSafeBlockConfirmations != nil {
+		c.RPCSafeBlockConfirmations = *dec.RPCSafeBlockConfirmations
+	}
 	if dec.Checkpoint != nil {
 		c.Checkpoint = dec.Checkpoint
 	}
@@ -371,9 +425,45 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.UseHeimdallApp != nil {
 		c.UseHeimdallApp = *dec.UseHeimdallApp
 	}
+	if dec.HeimdallSimulator != nil {
+		c.HeimdallSimulator = *dec.HeimdallSimulator
+	}
+	if dec.HeimdallCache != nil {
+		c.HeimdallCache = *dec.HeimdallCache
+	}
+	if dec.HeimdallEndpoints != nil {
+		c.HeimdallEndpoints = dec.HeimdallEndpoints
+	}
+	if dec.HeimdallgRPCEndpoints != nil {
+		c.HeimdallgRPCEndpoints = dec.HeimdallgRPCEndpoints
+	}
+	if dec.HeimdallCrossCheck != nil {
+		c.HeimdallCrossCheck = *dec.HeimdallCrossCheck
+	}
+	if dec.HeimdallVerify != nil {
+		c.HeimdallVerify = *dec.HeimdallVerify
+	}
+	if dec.HeimdallTendermintURL != nil {
+		c.HeimdallTendermintURL = *dec.HeimdallTendermintURL
+	}
+	if dec.HeimdallTrustedHeight != nil {
+		c.HeimdallTrustedHeight = *dec.HeimdallTrustedHeight
+	}
+	if dec.HeimdallTrustedHash != nil {
+		c.HeimdallTrustedHash = *dec.HeimdallTrustedHash
+	}
+	if dec.HeimdallBootstrap != nil {
+		c.HeimdallBootstrap = *dec.HeimdallBootstrap
+	}
+	if dec.HeimdallBootstrapVerify != nil {
+		c.HeimdallBootstrapVerify = *dec.HeimdallBootstrapVerify
+	}
 	if dec.BorLogs != nil {
 		c.BorLogs = *dec.BorLogs
 	}
+	if dec.BorLiveness != nil {
+		c.BorLiveness = *dec.BorLiveness
+	}
 	if dec.ParallelEVM != nil {
 		c.ParallelEVM = *dec.ParallelEVM
 	}
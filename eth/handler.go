@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -103,6 +104,8 @@ type handler struct {
 	snapSync  uint32 // Flag whether snap sync is enabled (gets disabled if we already have blocks)
 	acceptTxs uint32 // Flag whether we're considered synchronised (enables transaction processing)
 
+	bootstrapping uint32 // Flag whether the chain is bootstrapped from a heimdall checkpoint (disables the legacy sync)
+
 	checkpointNumber uint64      // Block number for the sync progress validator to cross reference
 	checkpointHash   common.Hash // Block hash for the sync progress validator to cross reference
 
@@ -115,6 +118,7 @@ type handler struct {
 	blockFetcher *fetcher.BlockFetcher
 	txFetcher    *fetcher.TxFetcher
 	peers        *peerSet
+	condTxPeers  *condTxPeerSet // Trusted peers the conditional transactions are relayed to
 	merger       *consensus.Merger
 
 	ethAPI *ethapi.BlockChainAPI // EthAPI to interact
@@ -149,6 +153,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		txpool:         config.TxPool,
 		chain:          config.Chain,
 		peers:          newPeerSet(),
+		condTxPeers:    newCondTxPeerSet(),
 		merger:         config.Merger,
 		ethAPI:         config.EthAPI,
 		requiredBlocks: config.RequiredBlocks,
@@ -225,8 +230,19 @@ func newHandler(config *handlerConfig) (*handler, error) {
 	} else if h.chain.Config().TerminalTotalDifficultyPassed {
 		log.Error("Chain configured post-merge, but without TTD. Are you debugging sync?")
 	}
+	// Report the headers received from peers to the bor double sign detector
+	var observeHeaders func(...*types.Header)
+	if borEngine, ok := h.chain.Engine().(*bor.Bor); ok {
+		observeHeaders = borEngine.DoubleSignDetector().Observe
+		h.downloader.SetHeaderObserver(observeHeaders)
+	}
+
 	// Construct the fetcher (short sync)
 	validator := func(header *types.Header) error {
+		if observeHeaders != nil {
+			observeHeaders(header)
+		}
+
 		// All the block fetcher activities should be disabled
 		// after the transition. Print the warning log.
 		if h.merger.PoSFinalized() {
@@ -739,6 +755,7 @@ func (h *handler) txBroadcastLoop() {
 		select {
 		case event := <-h.txsCh:
 			h.BroadcastTransactions(event.Txs)
+			h.relayConditionalTransactions(event.Txs)
 		case <-h.txsSub.Err():
 			return
 		}
@@ -54,28 +54,49 @@ func (h *ethHandler) fetchWhitelistCheckpoint(ctx context.Context, bor *bor.Bor,
 	return blockNum, blockHash, nil
 }
 
+// fetchCheckpointNumber fetches the number of the latest checkpoint from it's local
+// heimdall, and checks that it ends with the given block. It returns 0 if the
+// number isn't known, e.g. if another checkpoint was submitted in the meantime.
+func (h *ethHandler) fetchCheckpointNumber(ctx context.Context, bor *bor.Bor, endBlock uint64) uint64 {
+	count, err := bor.HeimdallClient.FetchCheckpointCount(ctx)
+	if err != nil || count <= 0 {
+		log.Debug("Failed to fetch checkpoint count", "err", err)
+		return 0
+	}
+
+	checkpoint, err := bor.HeimdallClient.FetchCheckpoint(ctx, count)
+	if err != nil || checkpoint.EndBlock.Uint64() != endBlock {
+		log.Debug("Failed to fetch checkpoint number", "number", count, "end", endBlock, "err", err)
+		return 0
+	}
+
+	return uint64(count)
+}
+
 // fetchWhitelistMilestone fetches the latest milestone from it's local heimdall
 // and verifies the data against bor data.
-func (h *ethHandler) fetchWhitelistMilestone(ctx context.Context, bor *bor.Bor, eth *Ethereum, verifier *borVerifier) (uint64, common.Hash, error) {
+func (h *ethHandler) fetchWhitelistMilestone(ctx context.Context, bor *bor.Bor, eth *Ethereum, verifier *borVerifier) (uint64, common.Hash, string, error) {
 	var (
 		num  uint64
 		hash common.Hash
+		id   string
 	)
 
 	// fetch latest milestone
 	milestone, err := bor.HeimdallClient.FetchMilestone(ctx)
 	if errors.Is(err, heimdall.ErrServiceUnavailable) {
 		log.Debug("Failed to fetch latest milestone for whitelisting", "err", err)
-		return num, hash, err
+		return num, hash, id, err
 	}
 
 	if err != nil {
 		log.Error("Failed to fetch latest milestone for whitelisting", "err", err)
-		return num, hash, errMilestone
+		return num, hash, id, errMilestone
 	}
 
 	num = milestone.EndBlock.Uint64()
 	hash = milestone.Hash
+	id = milestone.MilestoneID
 
 	log.Info("Got new milestone from heimdall", "start", milestone.StartBlock.Uint64(), "end", milestone.EndBlock.Uint64(), "hash", milestone.Hash.String())
 
@@ -85,10 +106,10 @@ func (h *ethHandler) fetchWhitelistMilestone(ctx context.Context, bor *bor.Bor,
 	_, err = verifier.verify(ctx, eth, h, milestone.StartBlock.Uint64(), milestone.EndBlock.Uint64(), milestone.Hash.String()[2:], false)
 	if err != nil {
 		h.downloader.UnlockSprint(milestone.EndBlock.Uint64())
-		return num, hash, err
+		return num, hash, id, err
 	}
 
-	return num, hash, nil
+	return num, hash, id, nil
 }
 
 func (h *ethHandler) fetchNoAckMilestone(ctx context.Context, bor *bor.Bor) (string, error) {
@@ -2,6 +2,7 @@ package eth
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
@@ -132,18 +133,19 @@ func fetchMilestoneTest(t *testing.T, heimdall *mockHeimdall, bor *bor.Bor, hand
 	// create a background context
 	ctx := context.Background()
 
-	_, _, err := handler.fetchWhitelistMilestone(ctx, bor, nil, verifier)
+	_, _, _, err := handler.fetchWhitelistMilestone(ctx, bor, nil, verifier)
 	require.Equal(t, err, errMilestone)
 
 	// create 4 mock checkpoints
 	milestones = createMockMilestones(4)
 
-	num, hash, err := handler.fetchWhitelistMilestone(ctx, bor, nil, verifier)
+	num, hash, id, err := handler.fetchWhitelistMilestone(ctx, bor, nil, verifier)
 
 	// Check if we have expected result
 	require.Equal(t, err, nil)
 	require.Equal(t, milestones[len(milestones)-1].EndBlock.Uint64(), num)
 	require.Equal(t, milestones[len(milestones)-1].Hash, hash)
+	require.Equal(t, milestones[len(milestones)-1].MilestoneID, id)
 }
 
 func getMockFetchCheckpointFn(number int64, err error) func(ctx context.Context) (int64, error) {
@@ -181,12 +183,13 @@ func createMockMilestones(count int) []*milestone.Milestone {
 
 	for i := 0; i < count; i++ {
 		milestones[i] = &milestone.Milestone{
-			Proposer:   common.Address{},
-			StartBlock: big.NewInt(startBlock),
-			EndBlock:   big.NewInt(startBlock + 255),
-			Hash:       common.Hash{},
-			BorChainID: "137",
-			Timestamp:  uint64(time.Now().Unix()),
+			Proposer:    common.Address{},
+			StartBlock:  big.NewInt(startBlock),
+			EndBlock:    big.NewInt(startBlock + 255),
+			Hash:        common.Hash{},
+			BorChainID:  "137",
+			MilestoneID: fmt.Sprintf("milestone-%d", i),
+			Timestamp:   uint64(time.Now().Unix()),
 		}
 		startBlock += 256
 	}
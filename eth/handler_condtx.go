@@ -0,0 +1,242 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/condtx"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/p2p"
+)
+
+var (
+	errCondTxPeerRegistered = errors.New("condtx peer already registered")
+
+	condTxRelayedMeter  = metrics.NewRegisteredMeter("eth/condtx/relayed", nil)
+	condTxAcceptedMeter = metrics.NewRegisteredMeter("eth/condtx/accepted", nil)
+	condTxRejectedMeter = metrics.NewRegisteredMeter("eth/condtx/rejected", nil)
+)
+
+// condTxHandler implements the condtx.Backend interface to relay the conditional
+// transactions between trusted peers. As the options of a conditional transaction
+// aren't part of its encoding, the transaction isn't propagated over `eth`. The
+// `condtx` protocol carries the options alongside it, to the trusted peers only
+// (e.g. the sentries of a validator), which re-validate them before accepting it.
+type condTxHandler handler
+
+// RunPeer is invoked when a peer joins on the `condtx` protocol. Only trusted
+// peers are accepted, the other ones are disconnected.
+func (h *condTxHandler) RunPeer(peer *condtx.Peer, hand condtx.Handler) error {
+	if !peer.Info().Network.Trusted {
+		peer.Log().Debug("Rejecting untrusted condtx peer")
+		return p2p.DiscUselessPeer
+	}
+
+	if err := h.condTxPeers.register(peer); err != nil {
+		peer.Log().Error("Failed to register condtx peer", "err", err)
+		return err
+	}
+	defer h.condTxPeers.unregister(peer.ID())
+
+	peer.Log().Debug("Relaying conditional transactions to trusted peer")
+
+	return hand(peer)
+}
+
+// Handle is invoked from a peer's message handler when it receives a new remote
+// message that the handler couldn't consume and serve itself.
+func (h *condTxHandler) Handle(peer *condtx.Peer, packet condtx.Packet) error {
+	switch packet := packet.(type) {
+	case *condtx.ConditionalTransactionsPacket:
+		return (*handler)(h).handleConditionalTransactions(peer, *packet)
+
+	default:
+		return fmt.Errorf("unexpected condtx packet type: %T", packet)
+	}
+}
+
+// handleConditionalTransactions re-validates the options of the conditional
+// transactions relayed by a trusted peer against the local chain, and adds the
+// valid ones to the pool.
+func (h *handler) handleConditionalTransactions(peer *condtx.Peer, packet condtx.ConditionalTransactionsPacket) error {
+	// Don't accept conditional transactions until the chain is synced, the known
+	// accounts can't be validated against a stale state anyway
+	if atomic.LoadUint32(&h.acceptTxs) == 0 {
+		return nil
+	}
+
+	header := h.chain.CurrentHeader()
+
+	statedb, err := h.chain.StateAt(header.Root)
+	if err != nil {
+		log.Debug("Failed to get state to validate conditional transactions", "number", header.Number, "err", err)
+		return nil
+	}
+
+	txs := make([]*types.Transaction, 0, len(packet))
+
+	for _, condTx := range packet {
+		tx, err := condTx.Transaction()
+		if err != nil {
+			return err
+		}
+
+		if err := validateConditionalOptions(header, statedb, tx.GetOptions()); err != nil {
+			condTxRejectedMeter.Mark(1)
+			peer.Log().Debug("Rejected conditional transaction", "hash", tx.Hash(), "err", err)
+
+			continue
+		}
+
+		txs = append(txs, tx)
+	}
+
+	for i, err := range h.txpool.AddRemotes(txs) {
+		if err != nil {
+			condTxRejectedMeter.Mark(1)
+			peer.Log().Trace("Failed to add conditional transaction", "hash", txs[i].Hash(), "err", err)
+
+			continue
+		}
+
+		condTxAcceptedMeter.Mark(1)
+	}
+
+	return nil
+}
+
+// validateConditionalOptions checks the options of a conditional transaction,
+// as done by bor_sendRawTransactionConditional on submission.
+func validateConditionalOptions(header *types.Header, statedb *state.StateDB, options *types.OptionsAA4337) error {
+	if err := header.ValidateBlockNumberOptions4337(options.BlockNumberMin, options.BlockNumberMax); err != nil {
+		return fmt.Errorf("out of block range: %w", err)
+	}
+
+	if err := header.ValidateTimestampOptions4337(options.TimestampMin, options.TimestampMax); err != nil {
+		return fmt.Errorf("out of time range: %w", err)
+	}
+
+	if err := options.KnownAccounts.ValidateLength(); err != nil {
+		return fmt.Errorf("known accounts limit exceeded: %w", err)
+	}
+
+	if err := statedb.ValidateKnownAccounts(options.KnownAccounts); err != nil {
+		return fmt.Errorf("known accounts mismatch: %w", err)
+	}
+
+	return nil
+}
+
+// relayConditionalTransactions sends the conditional transactions among the given
+// ones to the trusted `condtx` peers not knowing about them yet.
+func (h *handler) relayConditionalTransactions(txs types.Transactions) {
+	peers := h.condTxPeers.all()
+	if len(peers) == 0 {
+		return
+	}
+
+	var conditional []*condtx.ConditionalTransaction
+
+	for _, tx := range txs {
+		if tx.GetOptions() == nil {
+			continue
+		}
+
+		condTx, err := condtx.NewConditionalTransaction(tx)
+		if err != nil {
+			log.Debug("Failed to encode conditional transaction", "hash", tx.Hash(), "err", err)
+			continue
+		}
+
+		conditional = append(conditional, condTx)
+	}
+
+	if len(conditional) == 0 {
+		return
+	}
+
+	for _, peer := range peers {
+		var batch []*condtx.ConditionalTransaction
+
+		for _, condTx := range conditional {
+			if !peer.KnownTransaction(condTx.Tx.Hash()) {
+				batch = append(batch, condTx)
+			}
+		}
+
+		if len(batch) == 0 {
+			continue
+		}
+
+		condTxRelayedMeter.Mark(int64(len(batch)))
+
+		go func(peer *condtx.Peer, batch []*condtx.ConditionalTransaction) {
+			if err := peer.SendConditionalTransactions(batch); err != nil {
+				peer.Log().Debug("Failed to relay conditional transactions", "txs", len(batch), "err", err)
+			}
+		}(peer, batch)
+	}
+}
+
+// condTxPeerSet is the set of the trusted peers running the `condtx` protocol.
+type condTxPeerSet struct {
+	peers map[string]*condtx.Peer
+	lock  sync.RWMutex
+}
+
+func newCondTxPeerSet() *condTxPeerSet {
+	return &condTxPeerSet{
+		peers: make(map[string]*condtx.Peer),
+	}
+}
+
+func (ps *condTxPeerSet) register(peer *condtx.Peer) error {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	if _, ok := ps.peers[peer.ID()]; ok {
+		return errCondTxPeerRegistered
+	}
+
+	ps.peers[peer.ID()] = peer
+
+	return nil
+}
+
+func (ps *condTxPeerSet) unregister(id string) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	delete(ps.peers, id)
+}
+
+func (ps *condTxPeerSet) peer(id string) *condtx.Peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	return ps.peers[id]
+}
+
+func (ps *condTxPeerSet) len() int {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	return len(ps.peers)
+}
+
+func (ps *condTxPeerSet) all() []*condtx.Peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	list := make([]*condtx.Peer, 0, len(ps.peers))
+	for _, peer := range ps.peers {
+		list = append(list, peer)
+	}
+
+	return list
+}
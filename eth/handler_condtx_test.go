@@ -0,0 +1,109 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/condtx"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/stretchr/testify/require"
+)
+
+func newConditionalTransaction(t *testing.T, nonce uint64, slot, value common.Hash) *types.Transaction {
+	t.Helper()
+
+	tx, err := types.SignTx(types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 100000, big.NewInt(1), nil), types.HomesteadSigner{}, testKey)
+	require.NoError(t, err)
+
+	tx.PutOptions(&types.OptionsAA4337{
+		KnownAccounts: types.KnownAccounts{
+			testAddr: &types.Value{Storage: map[common.Hash]common.Hash{slot: value}},
+		},
+	})
+
+	return tx
+}
+
+// Tests that the conditional transactions relayed by a trusted peer are only
+// accepted if their known accounts match the local state, and that they aren't
+// relayed back to the peer.
+func TestConditionalTransactionsRelay(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler()
+	defer handler.close()
+
+	handler.handler.acceptTxs = 1 // mark synced to accept transactions
+
+	local, remote := p2p.MsgPipe()
+	defer local.Close()
+	defer remote.Close()
+
+	peer := condtx.NewFakePeer(condtx.CONDTX1, "0123456789abcdef", local)
+	require.NoError(t, handler.handler.condTxPeers.register(peer))
+
+	go condtx.Handle((*condTxHandler)(handler.handler), peer)
+
+	var (
+		valid   = newConditionalTransaction(t, 0, common.Hash{1}, common.Hash{})
+		invalid = newConditionalTransaction(t, 1, common.Hash{1}, common.Hash{2})
+	)
+
+	var packet condtx.ConditionalTransactionsPacket
+
+	for _, tx := range []*types.Transaction{valid, invalid} {
+		condTx, err := condtx.NewConditionalTransaction(tx)
+		require.NoError(t, err)
+
+		packet = append(packet, condTx)
+	}
+
+	require.NoError(t, p2p.Send(remote, condtx.ConditionalTransactionsMsg, packet))
+
+	require.Eventually(t, func() bool { return handler.txpool.Has(valid.Hash()) }, time.Second, 10*time.Millisecond)
+	require.False(t, handler.txpool.Has(invalid.Hash()))
+	require.NotNil(t, handler.txpool.Get(valid.Hash()).GetOptions())
+
+	// A conditional transaction entering the pool is relayed, except to the peers
+	// knowing about it already
+	relayed := newConditionalTransaction(t, 2, common.Hash{1}, common.Hash{})
+	handler.txpool.AddRemotes([]*types.Transaction{relayed, types.NewTransaction(3, common.Address{}, big.NewInt(0), 100000, big.NewInt(1), nil)})
+
+	msg, err := remote.ReadMsg()
+	require.NoError(t, err)
+	require.Equal(t, uint64(condtx.ConditionalTransactionsMsg), msg.Code)
+
+	var received condtx.ConditionalTransactionsPacket
+	require.NoError(t, msg.Decode(&received))
+	require.Len(t, received, 1)
+
+	tx, err := received[0].Transaction()
+	require.NoError(t, err)
+	require.Equal(t, relayed.Hash(), tx.Hash())
+	require.Equal(t, relayed.GetOptions().KnownAccounts, tx.GetOptions().KnownAccounts)
+}
+
+// Tests that untrusted peers are disconnected rather than served on `condtx`.
+func TestConditionalTransactionsUntrustedPeer(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler()
+	defer handler.close()
+
+	local, remote := p2p.MsgPipe()
+	defer local.Close()
+	defer remote.Close()
+
+	peer := condtx.NewPeer(condtx.CONDTX1, p2p.NewPeer(enode.ID{1}, "untrusted", nil), local)
+
+	err := (*condTxHandler)(handler.handler).RunPeer(peer, func(peer *condtx.Peer) error {
+		t.Fatal("message loop ran for untrusted peer")
+		return nil
+	})
+	require.ErrorIs(t, err, p2p.DiscUselessPeer)
+	require.Zero(t, handler.handler.condTxPeers.len())
+}
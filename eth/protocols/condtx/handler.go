@@ -0,0 +1,97 @@
+package condtx
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/p2p"
+)
+
+// Handler is a callback to invoke from an outside runner after the boilerplate
+// exchanges have passed.
+type Handler func(peer *Peer) error
+
+// Backend defines the callback methods to invoke on remote deliveries.
+type Backend interface {
+	// RunPeer is invoked when a peer joins on the `condtx` protocol. The handler
+	// should reject the peers whose conditional transactions aren't trusted. If
+	// the peer is accepted, control should be given back to the `handler` to
+	// process the inbound messages going forward.
+	RunPeer(peer *Peer, handler Handler) error
+
+	// Handle is a callback to be invoked when a data packet is received from
+	// the remote peer.
+	Handle(peer *Peer, packet Packet) error
+}
+
+// MakeProtocols constructs the P2P protocol definitions for `condtx`.
+func MakeProtocols(backend Backend) []p2p.Protocol {
+	protocols := make([]p2p.Protocol, len(ProtocolVersions))
+
+	for i, version := range ProtocolVersions {
+		version := version // Closure
+
+		protocols[i] = p2p.Protocol{
+			Name:    ProtocolName,
+			Version: version,
+			Length:  protocolLengths[version],
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return backend.RunPeer(NewPeer(version, p, rw), func(peer *Peer) error {
+					return Handle(backend, peer)
+				})
+			},
+		}
+	}
+
+	return protocols
+}
+
+// Handle is the callback invoked to manage the life cycle of a `condtx` peer.
+// When this function terminates, the peer is disconnected.
+func Handle(backend Backend, peer *Peer) error {
+	for {
+		if err := HandleMessage(backend, peer); err != nil {
+			peer.Log().Debug("Message handling failed in `condtx`", "err", err)
+			return err
+		}
+	}
+}
+
+// HandleMessage is invoked whenever an inbound message is received from a
+// remote peer on the `condtx` protocol. The remote connection is torn down upon
+// returning any error.
+func HandleMessage(backend Backend, peer *Peer) error {
+	// Read the next message from the remote peer, and ensure it's fully consumed
+	msg, err := peer.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+
+	if msg.Size > maxMessageSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
+	}
+
+	defer msg.Discard()
+
+	// Handle the message depending on its contents
+	switch msg.Code {
+	case ConditionalTransactionsMsg:
+		var txs ConditionalTransactionsPacket
+		if err := msg.Decode(&txs); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+
+		for i, tx := range txs {
+			// Validate and mark the remote transaction
+			if tx == nil || tx.Tx == nil {
+				return fmt.Errorf("%w: transaction %d is nil", errDecode, i)
+			}
+
+			peer.markTransaction(tx.Tx.Hash())
+		}
+
+		return backend.Handle(peer, &txs)
+
+	default:
+		return fmt.Errorf("%w: %v", errInvalidMsgCode, msg.Code)
+	}
+}
@@ -0,0 +1,82 @@
+package condtx
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+)
+
+// maxKnownTxs is the maximum transactions hashes to keep in the known list
+// before starting to randomly evict them.
+const maxKnownTxs = 32768
+
+// Peer is a collection of relevant information we have about a `condtx` peer.
+type Peer struct {
+	id string // Unique ID for the peer, cached
+
+	*p2p.Peer                   // The embedded P2P package peer
+	rw        p2p.MsgReadWriter // Input/output streams for condtx
+	version   uint              // Protocol version negotiated
+
+	knownTxs *lru.Cache[common.Hash, struct{}] // Set of transaction hashes known to be known by this peer
+
+	logger log.Logger // Contextual logger with the peer id injected
+}
+
+// NewPeer create a wrapper for a network connection and negotiated  protocol
+// version.
+func NewPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+	peer := NewFakePeer(version, p.ID().String(), rw)
+	peer.Peer = p
+
+	return peer
+}
+
+// NewFakePeer create a fake condtx peer without a backing p2p peer, for testing purposes.
+func NewFakePeer(version uint, id string, rw p2p.MsgReadWriter) *Peer {
+	return &Peer{
+		id:       id,
+		rw:       rw,
+		version:  version,
+		knownTxs: lru.NewCache[common.Hash, struct{}](maxKnownTxs),
+		logger:   log.New("peer", id[:8]),
+	}
+}
+
+// ID retrieves the peer's unique identifier.
+func (p *Peer) ID() string {
+	return p.id
+}
+
+// Version retrieves the peer's negotiated `condtx` protocol version.
+func (p *Peer) Version() uint {
+	return p.version
+}
+
+// Log overrides the P2P logger with the higher level one containing only the id.
+func (p *Peer) Log() log.Logger {
+	return p.logger
+}
+
+// KnownTransaction returns whether the peer is known to already have the
+// transaction.
+func (p *Peer) KnownTransaction(hash common.Hash) bool {
+	return p.knownTxs.Contains(hash)
+}
+
+// markTransaction marks a transaction as known for the peer, ensuring that it
+// will never be relayed back to it.
+func (p *Peer) markTransaction(hash common.Hash) {
+	p.knownTxs.Add(hash, struct{}{})
+}
+
+// SendConditionalTransactions relays a batch of conditional transactions to the
+// remote peer, marking them as known.
+func (p *Peer) SendConditionalTransactions(txs []*ConditionalTransaction) error {
+	for _, tx := range txs {
+		p.markTransaction(tx.Tx.Hash())
+	}
+
+	return p2p.Send(p.rw, ConditionalTransactionsMsg, txs)
+}
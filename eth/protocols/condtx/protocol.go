@@ -0,0 +1,93 @@
+package condtx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	CONDTX1 = 1
+)
+
+// ProtocolName is the official short name of the `condtx` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "condtx"
+
+// ProtocolVersions are the supported versions of the `condtx` protocol (first
+// is primary).
+var ProtocolVersions = []uint{CONDTX1}
+
+// protocolLengths are the number of implemented message corresponding to
+// different protocol versions.
+var protocolLengths = map[uint]uint64{CONDTX1: 1}
+
+// maxMessageSize is the maximum cap on the size of a protocol message.
+const maxMessageSize = 10 * 1024 * 1024
+
+const (
+	ConditionalTransactionsMsg = 0x00
+)
+
+var (
+	errMsgTooLarge    = errors.New("message too long")
+	errDecode         = errors.New("invalid message")
+	errInvalidMsgCode = errors.New("invalid message code")
+	errNoOptions      = errors.New("transaction is not conditional")
+)
+
+// Packet represents a p2p message in the `condtx` protocol.
+type Packet interface {
+	Name() string // Name returns a string corresponding to the message type.
+	Kind() byte   // Kind returns the message type.
+}
+
+// ConditionalTransaction is a transaction submitted with EIP-4337 options. The
+// options aren't part of the transaction encoding, they are relayed alongside
+// it in their JSON encoding.
+type ConditionalTransaction struct {
+	Tx      *types.Transaction
+	Options []byte
+}
+
+// NewConditionalTransaction bundles a transaction with its options for relaying.
+func NewConditionalTransaction(tx *types.Transaction) (*ConditionalTransaction, error) {
+	options := tx.GetOptions()
+	if options == nil {
+		return nil, errNoOptions
+	}
+
+	blob, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConditionalTransaction{Tx: tx, Options: blob}, nil
+}
+
+// Transaction returns the relayed transaction with its options put back in. The
+// options are only decoded, validating them is up to the receiver.
+func (c *ConditionalTransaction) Transaction() (*types.Transaction, error) {
+	if c.Tx == nil {
+		return nil, fmt.Errorf("%w: missing transaction", errDecode)
+	}
+
+	options := new(types.OptionsAA4337)
+	if err := json.Unmarshal(c.Options, options); err != nil {
+		return nil, fmt.Errorf("%w: options of transaction %v: %v", errDecode, c.Tx.Hash(), err)
+	}
+
+	c.Tx.PutOptions(options)
+
+	return c.Tx, nil
+}
+
+// ConditionalTransactionsPacket is the network packet relaying conditional
+// transactions to a trusted peer.
+type ConditionalTransactionsPacket []*ConditionalTransaction
+
+func (*ConditionalTransactionsPacket) Name() string { return "ConditionalTransactions" }
+func (*ConditionalTransactionsPacket) Kind() byte   { return ConditionalTransactionsMsg }
@@ -0,0 +1,78 @@
+package condtx
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that the options of a conditional transaction survive the encoding.
+func TestConditionalTransactionsEncoding(t *testing.T) {
+	t.Parallel()
+
+	var (
+		timestamp = uint64(1700000000)
+		options   = &types.OptionsAA4337{
+			KnownAccounts: types.KnownAccounts{
+				common.Address{1}: types.SingleFromHex("0x01"),
+				common.Address{2}: &types.Value{Storage: map[common.Hash]common.Hash{{1}: {2}}},
+			},
+			BlockNumberMin: big.NewInt(10),
+			TimestampMax:   &timestamp,
+		}
+		tx = types.NewTransaction(0, common.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+	)
+
+	if _, err := NewConditionalTransaction(tx); !errors.Is(err, errNoOptions) {
+		t.Fatalf("plain transaction: have %v, want %v", err, errNoOptions)
+	}
+
+	tx.PutOptions(options)
+
+	condTx, err := NewConditionalTransaction(tx)
+	if err != nil {
+		t.Fatalf("failed to bundle options: %v", err)
+	}
+
+	blob, err := rlp.EncodeToBytes(ConditionalTransactionsPacket{condTx})
+	if err != nil {
+		t.Fatalf("failed to encode packet: %v", err)
+	}
+
+	var packet ConditionalTransactionsPacket
+	if err := rlp.DecodeBytes(blob, &packet); err != nil {
+		t.Fatalf("failed to decode packet: %v", err)
+	}
+
+	if len(packet) != 1 {
+		t.Fatalf("transactions mismatch: have %d, want 1", len(packet))
+	}
+
+	decoded, err := packet[0].Transaction()
+	if err != nil {
+		t.Fatalf("failed to decode options: %v", err)
+	}
+
+	if decoded.Hash() != tx.Hash() {
+		t.Errorf("hash mismatch: have %v, want %v", decoded.Hash(), tx.Hash())
+	}
+
+	have, want := decoded.GetOptions(), options
+	if have.BlockNumberMin.Cmp(want.BlockNumberMin) != 0 || have.BlockNumberMax != nil || have.TimestampMin != nil || *have.TimestampMax != *want.TimestampMax {
+		t.Errorf("ranges mismatch: have %+v, want %+v", have, want)
+	}
+
+	if len(have.KnownAccounts) != 2 || *have.KnownAccounts[common.Address{1}].Single != *want.KnownAccounts[common.Address{1}].Single ||
+		have.KnownAccounts[common.Address{2}].Storage[common.Hash{1}] != (common.Hash{2}) {
+		t.Errorf("known accounts mismatch: have %v, want %v", have.KnownAccounts, want.KnownAccounts)
+	}
+
+	packet[0].Options = []byte("{")
+	if _, err := packet[0].Transaction(); !errors.Is(err, errDecode) {
+		t.Errorf("invalid options: have %v, want %v", err, errDecode)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -225,8 +226,30 @@ func (eth *Ethereum) stateAtTransaction(ctx context.Context, block *types.Block,
 	if txIndex == 0 && len(block.Transactions()) == 0 {
 		return nil, vm.BlockContext{}, statedb, release, nil
 	}
-	// Recompute transactions up to the target index.
+	// Recompute transactions up to the target index, with Block-STM unless it fails.
 	signer := types.MakeSigner(eth.blockchain.Config(), block.Number())
+
+	if txIndex > 0 && txIndex < len(block.Transactions()) {
+		cfg := vm.Config{ParallelSpeculativeProcesses: runtime.NumCPU()}
+
+		// Only the state after the preceding transactions is kept
+		var prestate *state.StateDB
+
+		err := core.IntermediateStatesParallel(eth.blockchain.Config(), eth.blockchain, block, statedb, txIndex, cfg, ctx, func(index int, statedb *state.StateDB) {
+			if index == txIndex {
+				prestate = statedb
+			}
+		})
+		if err == nil {
+			msg, _ := core.TransactionToMessage(block.Transactions()[txIndex], signer, block.BaseFee())
+			context := core.NewEVMBlockContext(block.Header(), eth.blockchain, nil)
+
+			return msg, context, prestate, release, nil
+		}
+
+		log.Debug("Falling back to serial block replay", "number", block.NumberU64(), "hash", block.Hash(), "err", err)
+	}
+
 	for idx, tx := range block.Transactions() {
 		// Assemble the transaction call message and return if the requested offset
 		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
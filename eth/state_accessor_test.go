@@ -0,0 +1,135 @@
+package eth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the state of a transaction regenerated with Block-STM matches the
+// one of a serial replay of the block.
+func TestStateAtTransactionParallel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		counter = common.HexToAddress("0xc0ffee")
+		reader  = common.HexToAddress("0xbeef")
+		keys    = make([]*ecdsaKey, 4)
+		gspec   = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				// SLOAD(0), ADD 1, SSTORE(0)
+				counter: {Code: common.FromHex("0x60005460010160005500"), Balance: common.Big0},
+				// SSTORE(0, BALANCE(COINBASE))
+				reader: {Code: common.FromHex("0x413160005500"), Balance: common.Big0},
+			},
+		}
+		signer = types.HomesteadSigner{}
+	)
+
+	for i := range keys {
+		key, _ := crypto.GenerateKey()
+		keys[i] = &ecdsaKey{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+		gspec.Alloc[keys[i].addr] = core.GenesisAccount{Balance: big.NewInt(params.Ether)}
+	}
+
+	// All the transactions increment the same counter, each one depends on the
+	// previous one. In the second block, one of them reads the fees of the
+	// previous ones, the block is executed again without delaying the fees.
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, func(i int, b *core.BlockGen) {
+		for j, key := range keys {
+			to := counter
+			if i == 1 && j == 2 {
+				to = reader
+			}
+
+			tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(key.addr), to, common.Big0, 100000, b.BaseFee(), nil), signer, key.key)
+			b.AddTx(tx)
+
+			tx, _ = types.SignTx(types.NewTransaction(b.TxNonce(key.addr), keys[0].addr, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), signer, key.key)
+			b.AddTx(tx)
+		}
+	})
+
+	chain, err := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	require.NoError(t, err)
+
+	defer chain.Stop()
+
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	eth := &Ethereum{blockchain: chain, chainDb: db}
+
+	for _, block := range blocks {
+		parent := chain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+
+		statedb, err := chain.StateAt(parent.Root())
+		require.NoError(t, err)
+
+		// The states mustn't fall back to a serial replay, and are delivered in
+		// order
+		var delivered []int
+
+		err = core.IntermediateStatesParallel(chain.Config(), chain, block, statedb, len(block.Transactions()), vm.Config{ParallelSpeculativeProcesses: runtime.NumCPU()}, context.Background(), func(index int, _ *state.StateDB) {
+			delivered = append(delivered, index)
+		})
+		require.NoError(t, err)
+
+		for i, index := range delivered {
+			require.Equal(t, i, index)
+		}
+
+		require.Len(t, delivered, len(block.Transactions())+1)
+
+		// Replay the block serially to get the expected states
+
+		blockContext := core.NewEVMBlockContext(block.Header(), chain, nil)
+		roots := make([]common.Hash, len(block.Transactions()))
+
+		for i, tx := range block.Transactions() {
+			roots[i] = statedb.IntermediateRoot(true)
+
+			msg, err := core.TransactionToMessage(tx, signer, block.BaseFee())
+			require.NoError(t, err)
+
+			statedb.SetTxContext(tx.Hash(), i)
+
+			_, err = core.ApplyMessage(vm.NewEVM(blockContext, core.NewEVMTxContext(msg), statedb, chain.Config(), vm.Config{}), msg, new(core.GasPool).AddGas(tx.Gas()), nil)
+			require.NoError(t, err)
+		}
+
+		for i, tx := range block.Transactions() {
+			msg, _, statedb, release, err := eth.stateAtTransaction(context.Background(), block, i, 0)
+			require.NoError(t, err, "block %d, tx %d", block.NumberU64(), i)
+
+			sender, _ := types.Sender(signer, tx)
+			require.Equal(t, sender, msg.From)
+			require.Equal(t, roots[i], statedb.IntermediateRoot(true), "block %d, tx %d", block.NumberU64(), i)
+
+			if release != nil {
+				release()
+			}
+		}
+	}
+}
+
+type ecdsaKey struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
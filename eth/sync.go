@@ -74,6 +74,9 @@ type chainSyncer struct {
 	warned      time.Time
 	peerEventCh chan struct{}
 	doneCh      chan error // non-nil when sync is running
+
+	syncPeerCh chan *eth.Peer
+	syncPeer   *eth.Peer // Peer a sync was requested from, synced from once no sync is running
 }
 
 // chainSyncOp is a scheduled sync operation.
@@ -89,6 +92,7 @@ func newChainSyncer(handler *handler) *chainSyncer {
 	return &chainSyncer{
 		handler:     handler,
 		peerEventCh: make(chan struct{}),
+		syncPeerCh:  make(chan *eth.Peer),
 	}
 }
 
@@ -104,6 +108,18 @@ func (cs *chainSyncer) handlePeerEvent(peer *eth.Peer) bool {
 	}
 }
 
+// requestSync requests a sync from the given peer, e.g. the one serving the
+// chain of heimdall while recovering the chain. The sync is started once the
+// running one, if any, is done, with the same rules as the regular syncs.
+func (cs *chainSyncer) requestSync(peer *eth.Peer) bool {
+	select {
+	case cs.syncPeerCh <- peer:
+		return true
+	case <-cs.handler.quitSync:
+		return false
+	}
+}
+
 // loop runs in its own goroutine and launches the sync when necessary.
 func (cs *chainSyncer) loop() {
 	defer cs.handler.wg.Done()
@@ -127,6 +143,8 @@ func (cs *chainSyncer) loop() {
 		select {
 		case <-cs.peerEventCh:
 			// Peer information changed, recheck.
+		case peer := <-cs.syncPeerCh:
+			cs.syncPeer = peer
 		case err := <-cs.doneCh:
 			cs.doneCh = nil
 			cs.force.Reset(forceSyncCycle)
@@ -164,6 +182,11 @@ func (cs *chainSyncer) nextSyncOp() *chainSyncOp {
 	if cs.doneCh != nil {
 		return nil // Sync already running
 	}
+	// While the chain is bootstrapped from a heimdall checkpoint, the blocks are
+	// backfilled from the checkpoint rather than synced from the best peer.
+	if atomic.LoadUint32(&cs.handler.bootstrapping) == 1 {
+		return nil
+	}
 	// If a beacon client once took over control, disable the entire legacy sync
 	// path from here on end. Note, there is a slight "race" between reaching TTD
 	// and the beacon client taking over. The downloader will enforce that nothing
@@ -175,6 +198,15 @@ func (cs *chainSyncer) nextSyncOp() *chainSyncOp {
 	if cs.handler.chain.Config().TerminalTotalDifficultyPassed || cs.handler.merger.TDDReached() {
 		return nil
 	}
+	// Sync from the requested peer, if it is still connected
+	if peer := cs.syncPeer; peer != nil {
+		cs.syncPeer = nil
+
+		if cs.handler.peers.peer(peer.ID()) != nil {
+			mode, _ := cs.modeAndLocalHead()
+			return peerToSyncOp(mode, peer)
+		}
+	}
 	// Ensure we're at minimum peer count.
 	minPeers := defaultMinSyncPeers
 	if cs.forced {
@@ -110,6 +110,9 @@ type Backend interface {
 // API is the collection of tracing APIs exposed over the private debugging endpoint.
 type API struct {
 	backend Backend
+
+	// Testing hooks
+	prestatesHook func(*types.Block, int) // Method to call upon replaying a block, with the number of transactions traced from the states generated with Block-STM
 }
 
 // NewAPI creates a new API definition for the tracing methods of the Ethereum service.
@@ -763,7 +766,9 @@ func (api *API) StandardTraceBadBlockToFile(ctx context.Context, hash common.Has
 // executes all the transactions contained within. The return value will be one item
 // per transaction, dependent on the requested tracer.
 // We always run parallel execution
-// One thread runs along and executes txs without tracing enabled to generate their prestate.
+// The prestates of the transactions are generated with Block-STM, or by one thread running
+// along and executing txs without tracing enabled if that fails. Either way, only the
+// prestates waiting for a worker thread are held at once.
 // Worker threads take the tasks and the prestate and trace them.
 func (api *API) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig) ([]*txTraceResult, error) {
 	if config == nil {
@@ -887,20 +892,54 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 		london = api.backend.ChainConfig().IsLondon(block.Number())
 	}
 
+	// Generate the state snapshots of the block with Block-STM, each transaction
+	// is sent over for tracing as soon as its state is settled. The state sync
+	// transaction is traced on top of the state after the last one. If it fails,
+	// the transactions which weren't sent yet are replayed serially.
+	var sent int
+
+	if !ioflag {
+		cfg := vm.Config{ParallelSpeculativeProcesses: runtime.NumCPU()}
+
+		err = core.IntermediateStatesParallel(api.backend.ChainConfig(), api.chainContext(ctx), block, statedb, len(block.Transactions()), cfg, ctx, func(index int, prestate *state.StateDB) {
+			if index >= len(txs) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+			case jobs <- &txTraceTask{statedb: prestate.Copy(), index: index}:
+				sent++
+			}
+		})
+		if err != nil {
+			log.Debug("Falling back to serial block replay", "number", block.NumberU64(), "hash", blockHash, "sent", sent, "err", err)
+		}
+	}
+
+	if api.prestatesHook != nil {
+		api.prestatesHook(block, sent)
+	}
+
 txloop:
 	for i, tx := range txs {
+		if sent == len(txs) {
+			break
+		}
+
 		if ioflag {
 			// copy of statedb
 			statedb = statedb.Copy()
 		}
 
 		// Send the trace task over for execution
-		task := &txTraceTask{statedb: statedb.Copy(), index: i}
-		select {
-		case <-ctx.Done():
-			failed = ctx.Err()
-			break txloop
-		case jobs <- task:
+		if i >= sent {
+			select {
+			case <-ctx.Done():
+				failed = ctx.Err()
+				break txloop
+			case jobs <- &txTraceTask{statedb: statedb.Copy(), index: i}:
+			}
 		}
 
 		// Generate the next state snapshot fast without tracing
@@ -496,6 +496,85 @@ func TestTraceBlock(t *testing.T) {
 	}
 }
 
+func TestTraceBlockParallel(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test accounts, all of them incrementing the same counter
+	var (
+		accounts = newAccounts(5)
+		counter  = common.HexToAddress("0xc0ffee")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				// SLOAD(0), ADD 1, SSTORE(0)
+				counter: {Code: common.FromHex("0x60005460010160005500"), Balance: common.Big0},
+			},
+		}
+	)
+
+	for _, account := range accounts {
+		genesis.Alloc[account.addr] = core.GenesisAccount{Balance: big.NewInt(params.Ether)}
+	}
+
+	genBlocks := 3
+	signer := types.HomesteadSigner{}
+	backend := newTestBackend(t, genBlocks, genesis, func(i int, b *core.BlockGen) {
+		for j := range accounts {
+			nonce := b.TxNonce(accounts[j].addr)
+			tx, _ := types.SignTx(types.NewTransaction(nonce, counter, common.Big0, 100000, b.BaseFee(), nil), signer, accounts[j].key)
+			b.AddTx(tx)
+
+			nonce = b.TxNonce(accounts[j].addr)
+			tx, _ = types.SignTx(types.NewTransaction(nonce, accounts[(j+1)%len(accounts)].addr, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), signer, accounts[j].key)
+			b.AddTx(tx)
+		}
+	})
+
+	defer backend.chain.Stop()
+	api := NewAPI(backend)
+
+	// The block must be replayed from the states generated with Block-STM
+	var sent int
+
+	api.prestatesHook = func(block *types.Block, n int) {
+		sent = n
+	}
+
+	for number := 1; number <= genBlocks; number++ {
+		block := backend.chain.GetBlockByNumber(uint64(number))
+
+		sent = 0
+
+		results, err := api.TraceBlockByNumber(context.Background(), rpc.BlockNumber(number), nil)
+		if err != nil {
+			t.Fatalf("block %d: failed to trace block: %v", number, err)
+		}
+
+		if sent != len(block.Transactions()) {
+			t.Fatalf("block %d: block not replayed with block-stm, have %d states, want %d", number, sent, len(block.Transactions()))
+		}
+
+		if len(results) != len(block.Transactions()) {
+			t.Fatalf("block %d: result count mismatch, have %d, want %d", number, len(results), len(block.Transactions()))
+		}
+
+		// Each transaction traced on its own replays the block serially
+		for i, tx := range block.Transactions() {
+			result, err := api.TraceTransaction(context.Background(), tx.Hash(), nil)
+			if err != nil {
+				t.Fatalf("block %d, tx %d: failed to trace transaction: %v", number, i, err)
+			}
+
+			have, _ := json.Marshal(results[i].Result)
+			want, _ := json.Marshal(result)
+
+			if !bytes.Equal(have, want) {
+				t.Errorf("block %d, tx %d: result mismatch, have\n%v\n, want\n%v\n", number, i, string(have), string(want))
+			}
+		}
+	}
+}
+
 func TestIOdump(t *testing.T) {
 	t.Parallel()
 
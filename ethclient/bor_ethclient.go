@@ -39,3 +39,31 @@ func (ec *Client) GetBorBlockReceipt(ctx context.Context, hash common.Hash) (*ty
 
 	return r, err
 }
+
+// GetFinality returns the blocks the 'finalized' and 'safe' block tags resolve
+// to, with the heimdall ids of the milestone and checkpoint they're resolved from.
+func (ec *Client) GetFinality(ctx context.Context) (*types.Finality, error) {
+	var finality *types.Finality
+	if err := ec.c.CallContext(ctx, &finality, "bor_getFinality"); err != nil {
+		return nil, err
+	}
+
+	return finality, nil
+}
+
+// GetMilestoneVotes returns the votes of the node on the milestones ending in
+// the range [from, to].
+func (ec *Client) GetMilestoneVotes(ctx context.Context, from uint64, to uint64) ([]*types.MilestoneVote, error) {
+	var votes []*types.MilestoneVote
+	if err := ec.c.CallContext(ctx, &votes, "bor_getMilestoneVotes", from, to); err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+// SubscribeFinalizedHeads subscribes to notifications about the milestones and
+// checkpoints whitelisted by the node, which advance the finality of the chain.
+func (ec *Client) SubscribeFinalizedHeads(ctx context.Context, ch chan<- *types.FinalizedHead) (ethereum.Subscription, error) {
+	return ec.c.Subscribe(ctx, "bor", ch, "finalizedHeads")
+}
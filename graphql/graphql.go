@@ -1304,11 +1304,13 @@ func (r *Resolver) Block(ctx context.Context, args struct {
 	var numberOrHash rpc.BlockNumberOrHash
 
 	if args.Number != nil {
-		if *args.Number < 0 {
+		number := rpc.BlockNumber(*args.Number)
+
+		// The finalized and safe blocks are the only special numbers resolved
+		if number < 0 && number != rpc.FinalizedBlockNumber && number != rpc.SafeBlockNumber {
 			return nil, nil
 		}
 
-		number := rpc.BlockNumber(*args.Number)
 		numberOrHash = rpc.BlockNumberOrHashWithNumber(number)
 	} else if args.Hash != nil {
 		numberOrHash = rpc.BlockNumberOrHashWithHash(*args.Hash, false)
@@ -331,7 +331,8 @@ const schema string = `
 
     type Query {
         # Block fetches an Ethereum block by number or by hash. If neither is
-        # supplied, the most recent known block is returned.
+        # supplied, the most recent known block is returned. The finalized and
+        # safe blocks are fetched with the numbers -3 and -4.
         block(number: Long, hash: Bytes32): Block
         # Blocks returns all the blocks between two numbers, inclusive. If
         # to is not supplied, it defaults to the most recent known block.
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 )
 
 // NotFound is returned by API methods if the requested item does not exist.
@@ -254,8 +255,11 @@ type ChainValidator interface {
 	IsValidChain(currentHeader *types.Header, chain []*types.Header) (bool, error)
 	GetWhitelistedCheckpoint() (bool, uint64, common.Hash)
 	GetWhitelistedMilestone() (bool, uint64, common.Hash)
-	ProcessCheckpoint(endBlockNum uint64, endBlockHash common.Hash)
-	ProcessMilestone(endBlockNum uint64, endBlockHash common.Hash)
+	GetWhitelistedCheckpointNumber() uint64
+	GetWhitelistedMilestoneID() string
+	SubscribeFinality(ch chan<- *types.FinalityBlock) event.Subscription
+	ProcessCheckpoint(endBlockNum uint64, endBlockHash common.Hash, checkpointNumber uint64)
+	ProcessMilestone(endBlockNum uint64, endBlockHash common.Hash, milestoneID string)
 	ProcessFutureMilestone(num uint64, hash common.Hash)
 	PurgeWhitelistedCheckpoint()
 	PurgeWhitelistedMilestone()
@@ -137,6 +137,16 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"milestone": func() (MarkDownCommand, error) {
+			return &MilestoneCommand{
+				UI: ui,
+			}, nil
+		},
+		"milestone votes": func() (MarkDownCommand, error) {
+			return &MilestoneVotesCommand{
+				UI: ui,
+			}, nil
+		},
 		"peers": func() (MarkDownCommand, error) {
 			return &PeersCommand{
 				UI: ui,
@@ -199,6 +209,21 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"slashing-protection": func() (MarkDownCommand, error) {
+			return &SlashingProtectionCommand{
+				UI: ui,
+			}, nil
+		},
+		"slashing-protection export": func() (MarkDownCommand, error) {
+			return &SlashingProtectionExportCommand{
+				slashingProtectionFlags{Meta: meta},
+			}, nil
+		},
+		"slashing-protection import": func() (MarkDownCommand, error) {
+			return &SlashingProtectionImportCommand{
+				slashingProtectionFlags{Meta: meta},
+			}, nil
+		},
 	}
 }
 
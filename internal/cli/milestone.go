@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+
+	"github.com/mitchellh/cli"
+)
+
+// MilestoneCommand is the command to group the milestone commands
+type MilestoneCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *MilestoneCommand) MarkDown() string {
+	items := []string{
+		"# Milestone",
+		"The ```milestone``` command groups actions on the milestones proposed by heimdall:",
+		"- [```milestone votes```](./milestone_votes.md): List the votes of the node on the milestones.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *MilestoneCommand) Help() string {
+	return `Usage: bor milestone <subcommand>
+
+  This command groups actions on the milestones proposed by heimdall.
+
+  List the votes of the node on the milestones ending in a range of blocks:
+
+    $ bor milestone votes --from <number> --to <number>`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *MilestoneCommand) Synopsis() string {
+	return "Milestone related commands"
+}
+
+// Run implements the cli.Command interface
+func (c *MilestoneCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// MilestoneVotesCommand is the command to list the votes of the node on the milestones
+type MilestoneVotesCommand struct {
+	UI cli.Ui
+
+	endpoint string
+	from     uint64
+	to       uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *MilestoneVotesCommand) MarkDown() string {
+	items := []string{
+		"# Milestone votes",
+		"The ```milestone votes``` command lists the votes of the node on the milestones ending in a range of blocks, with the reason of the rejected ones.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *MilestoneVotesCommand) Help() string {
+	return `Usage: bor milestone votes --from <number> --to <number>
+
+  Lists the votes of the node on the milestones ending in a range of blocks
+
+  ` + c.Flags().Help()
+}
+
+func (c *MilestoneVotesCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("milestone votes")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "endpoint",
+		Usage: "IPC or RPC endpoint of the node, the IPC endpoint of the default data directory if empty",
+		Value: &c.endpoint,
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "from",
+		Usage: "First end block of the milestones",
+		Value: &c.from,
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "to",
+		Usage: "Last end block of the milestones",
+		Value: &c.to,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *MilestoneVotesCommand) Synopsis() string {
+	return "List the votes of the node on the milestones"
+}
+
+// Run implements the cli.Command interface
+func (c *MilestoneVotesCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	client, err := dialRPC(c.endpoint)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer client.Close()
+
+	votes, err := ethclient.NewClient(client).GetMilestoneVotes(context.Background(), c.from, c.to)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatMilestoneVotes(votes))
+
+	return 0
+}
+
+func formatMilestoneVotes(votes []*types.MilestoneVote) string {
+	if len(votes) == 0 {
+		return "No milestone votes found"
+	}
+
+	rows := make([]string, len(votes)+1)
+	rows[0] = "Time|Start|End|Hash|Milestone ID|Vote|Reason"
+
+	for i, v := range votes {
+		rows[i+1] = fmt.Sprintf("%s|%d|%d|%s|%s|%v|%s",
+			v.Time.Format(time.RFC3339),
+			v.StartBlock,
+			v.EndBlock,
+			v.Hash,
+			v.MilestoneID,
+			v.Vote,
+			v.Reason)
+	}
+
+	return formatList(rows)
+}
@@ -91,6 +91,9 @@ type Config struct {
 	// BorLogs enables bor log retrieval
 	BorLogs bool `hcl:"bor.logs,optional" toml:"bor.logs,optional"`
 
+	// BorLiveness enables tracking of the block production of the validators
+	BorLiveness bool `hcl:"bor.liveness,optional" toml:"bor.liveness,optional"`
+
 	// Ethstats is the address of the ethstats server to send telemetry
 	Ethstats string `hcl:"ethstats,optional" toml:"ethstats,optional"`
 
@@ -260,6 +263,39 @@ type HeimdallConfig struct {
 
 	// UseHeimdallApp is used to fetch data from heimdall app when running heimdall as a child process
 	UseHeimdallApp bool `hcl:"bor.useheimdallapp,optional" toml:"bor.useheimdallapp,optional"`
+
+	// Simulator is the path to the config of an in-process heimdall simulator
+	Simulator string `hcl:"simulator,optional" toml:"simulator,optional"`
+
+	// Cache persists spans, state-sync events and checkpoints fetched from heimdall
+	Cache bool `hcl:"cache,optional" toml:"cache,optional"`
+
+	// Endpoints are additional heimdall REST endpoints to fail over to
+	Endpoints []string `hcl:"endpoints,optional" toml:"endpoints,optional"`
+
+	// GRPCEndpoints are additional heimdall gRPC endpoints to fail over to
+	GRPCEndpoints []string `hcl:"grpc-endpoints,optional" toml:"grpc-endpoints,optional"`
+
+	// CrossCheck confirms span and milestone responses with a second endpoint
+	CrossCheck bool `hcl:"cross-check,optional" toml:"cross-check,optional"`
+
+	// Verify checks spans, checkpoints and milestones against proofs from heimdall's tendermint node
+	Verify bool `hcl:"verify,optional" toml:"verify,optional"`
+
+	// TendermintURL is the tendermint RPC of the heimdall node used for verification
+	TendermintURL string `hcl:"tendermint-url,optional" toml:"tendermint-url,optional"`
+
+	// TrustedHeight is the height of the heimdall header trusted on the first start of the verification
+	TrustedHeight uint64 `hcl:"trusted-height,optional" toml:"trusted-height,optional"`
+
+	// TrustedHash is the hash of the heimdall header trusted on the first start of the verification
+	TrustedHash string `hcl:"trusted-hash,optional" toml:"trusted-hash,optional"`
+
+	// Bootstrap syncs a new node from the latest heimdall checkpoint rather than from genesis
+	Bootstrap bool `hcl:"bootstrap,optional" toml:"bootstrap,optional"`
+
+	// BootstrapVerify is the number of checkpoints preceding the bootstrap anchor verified before the legacy sync is enabled, the older ones are verified afterwards
+	BootstrapVerify uint64 `hcl:"bootstrap-verify,optional" toml:"bootstrap-verify,optional"`
 }
 
 type TxPoolConfig struct {
@@ -297,6 +333,9 @@ type TxPoolConfig struct {
 	// lifetime is the maximum amount of time non-executable transaction are queued
 	LifeTime    time.Duration `hcl:"-,optional" toml:"-"`
 	LifeTimeRaw string        `hcl:"lifetime,optional" toml:"lifetime,optional"`
+
+	// ConditionalRelay relays the conditional transactions to the trusted peers
+	ConditionalRelay bool `hcl:"conditionalrelay,optional" toml:"conditionalrelay,optional"`
 }
 
 type SealerConfig struct {
@@ -321,6 +360,19 @@ type SealerConfig struct {
 	RecommitRaw string        `hcl:"recommit,optional" toml:"recommit,optional"`
 
 	CommitInterruptFlag bool `hcl:"commitinterrupt,optional" toml:"commitinterrupt,optional"`
+
+	// ParallelBuild executes the transactions of the mined blocks in parallel
+	ParallelBuild bool `hcl:"parallelbuild,optional" toml:"parallelbuild,optional"`
+
+	// Signer has the settings of the external signer sealing the blocks
+	Signer *SealerSignerConfig `hcl:"signer,block" toml:"signer,block"`
+}
+
+type SealerSignerConfig struct {
+	// Endpoint is the url or ipc path of the external signer (e.g. clef) used to seal
+	// the blocks instead of the local keystore. Any remote signing service serving
+	// account_signBorHeader can be used.
+	Endpoint string `hcl:"endpoint,optional" toml:"endpoint,optional"`
 }
 
 type JsonRPCConfig struct {
@@ -340,6 +392,12 @@ type JsonRPCConfig struct {
 	// TxFeeCap is the global transaction fee cap for send-transaction variants
 	TxFeeCap float64 `hcl:"txfeecap,optional" toml:"txfeecap,optional"`
 
+	// SafeBlock is the policy the 'safe' block tag is resolved with, either "milestone" or "checkpoint"
+	SafeBlock string `hcl:"safeblock,optional" toml:"safeblock,optional"`
+
+	// SafeBlockConfirmations is the number of confirmations after which a block is safe with the milestone policy
+	SafeBlockConfirmations uint64 `hcl:"safeblockconfirmations,optional" toml:"safeblockconfirmations,optional"`
+
 	// Http has the json-rpc http related settings
 	Http *APIConfig `hcl:"http,block" toml:"http,block"`
 
@@ -592,6 +650,8 @@ type ParallelEVMConfig struct {
 	Enable bool `hcl:"enable,optional" toml:"enable,optional"`
 
 	SpeculativeProcesses int `hcl:"procs,optional" toml:"procs,optional"`
+
+	AdaptiveScheduling bool `hcl:"adaptive,optional" toml:"adaptive,optional"`
 }
 
 func DefaultConfig() *Config {
@@ -634,26 +694,31 @@ func DefaultConfig() *Config {
 			},
 		},
 		Heimdall: &HeimdallConfig{
-			URL:         "http://localhost:1317",
-			Without:     false,
-			GRPCAddress: "",
+			URL:             "http://localhost:1317",
+			Without:         false,
+			GRPCAddress:     "",
+			Endpoints:       []string{},
+			GRPCEndpoints:   []string{},
+			TendermintURL:   "http://localhost:26657",
+			BootstrapVerify: 64,
 		},
 		SyncMode: "full",
 		GcMode:   "full",
 		Snapshot: true,
 		BorLogs:  false,
 		TxPool: &TxPoolConfig{
-			Locals:       []string{},
-			NoLocals:     false,
-			Journal:      "transactions.rlp",
-			Rejournal:    1 * time.Hour,
-			PriceLimit:   1, // geth's default
-			PriceBump:    10,
-			AccountSlots: 16,
-			GlobalSlots:  32768,
-			AccountQueue: 16,
-			GlobalQueue:  32768,
-			LifeTime:     3 * time.Hour,
+			Locals:           []string{},
+			NoLocals:         false,
+			Journal:          "transactions.rlp",
+			Rejournal:        1 * time.Hour,
+			PriceLimit:       1, // geth's default
+			PriceBump:        10,
+			AccountSlots:     16,
+			GlobalSlots:      32768,
+			AccountQueue:     16,
+			GlobalQueue:      32768,
+			LifeTime:         3 * time.Hour,
+			ConditionalRelay: false,
 		},
 		Sealer: &SealerConfig{
 			Enabled:             false,
@@ -663,6 +728,10 @@ func DefaultConfig() *Config {
 			ExtraData:           "",
 			Recommit:            125 * time.Second,
 			CommitInterruptFlag: true,
+			ParallelBuild:       false,
+			Signer: &SealerSignerConfig{
+				Endpoint: "",
+			},
 		},
 		Gpo: &GpoConfig{
 			Blocks:           20,
@@ -678,6 +747,7 @@ func DefaultConfig() *Config {
 			GasCap:              ethconfig.Defaults.RPCGasCap,
 			TxFeeCap:            ethconfig.Defaults.RPCTxFeeCap,
 			RPCEVMTimeout:       ethconfig.Defaults.RPCEVMTimeout,
+			SafeBlock:           ethconfig.Defaults.RPCSafeBlock,
 			AllowUnprotectedTxs: false,
 			EnablePersonal:      false,
 			Http: &APIConfig{
@@ -787,6 +857,7 @@ func DefaultConfig() *Config {
 		ParallelEVM: &ParallelEVMConfig{
 			Enable:               true,
 			SpeculativeProcesses: 8,
+			AdaptiveScheduling:   false,
 		},
 	}
 }
@@ -926,6 +997,17 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	n.RunHeimdall = c.Heimdall.RunHeimdall
 	n.RunHeimdallArgs = c.Heimdall.RunHeimdallArgs
 	n.UseHeimdallApp = c.Heimdall.UseHeimdallApp
+	n.HeimdallSimulator = c.Heimdall.Simulator
+	n.HeimdallCache = c.Heimdall.Cache
+	n.HeimdallEndpoints = c.Heimdall.Endpoints
+	n.HeimdallgRPCEndpoints = c.Heimdall.GRPCEndpoints
+	n.HeimdallCrossCheck = c.Heimdall.CrossCheck
+	n.HeimdallVerify = c.Heimdall.Verify
+	n.HeimdallTendermintURL = c.Heimdall.TendermintURL
+	n.HeimdallTrustedHeight = c.Heimdall.TrustedHeight
+	n.HeimdallTrustedHash = c.Heimdall.TrustedHash
+	n.HeimdallBootstrap = c.Heimdall.Bootstrap
+	n.HeimdallBootstrapVerify = c.Heimdall.BootstrapVerify
 
 	// Developer Fake Author for producing blocks without authorisation on bor consensus
 	n.DevFakeAuthor = c.DevFakeAuthor
@@ -957,6 +1039,7 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.TxPool.AccountQueue = c.TxPool.AccountQueue
 		n.TxPool.GlobalQueue = c.TxPool.GlobalQueue
 		n.TxPool.Lifetime = c.TxPool.LifeTime
+		n.ConditionalTxRelay = c.TxPool.ConditionalRelay
 	}
 
 	// miner options
@@ -966,6 +1049,7 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.Miner.GasCeil = c.Sealer.GasCeil
 		n.Miner.ExtraData = []byte(c.Sealer.ExtraData)
 		n.Miner.CommitInterruptFlag = c.Sealer.CommitInterruptFlag
+		n.Miner.ParallelBuild = c.Sealer.ParallelBuild
 
 		if etherbase := c.Sealer.Etherbase; etherbase != "" {
 			if !common.IsHexAddress(etherbase) {
@@ -1138,6 +1222,15 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 
 	n.RPCTxFeeCap = c.JsonRPC.TxFeeCap
 
+	switch c.JsonRPC.SafeBlock {
+	case ethconfig.SafeBlockMilestone, ethconfig.SafeBlockCheckpoint:
+		n.RPCSafeBlock = c.JsonRPC.SafeBlock
+	default:
+		return nil, fmt.Errorf("safe block policy '%s' not found", c.JsonRPC.SafeBlock)
+	}
+
+	n.RPCSafeBlockConfirmations = c.JsonRPC.SafeBlockConfirmations
+
 	// sync mode. It can either be "fast", "full" or "snap". We disable
 	// for now the "light" mode.
 	switch c.SyncMode {
@@ -1179,10 +1272,12 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	}
 
 	n.BorLogs = c.BorLogs
+	n.BorLiveness = c.BorLiveness
 	n.DatabaseHandles = dbHandles
 
 	n.ParallelEVM.Enable = c.ParallelEVM.Enable
 	n.ParallelEVM.SpeculativeProcesses = c.ParallelEVM.SpeculativeProcesses
+	n.ParallelEVM.AdaptiveScheduling = c.ParallelEVM.AdaptiveScheduling
 	n.RPCReturnDataLimit = c.RPCReturnDataLimit
 
 	if c.Ancient != "" {
@@ -116,6 +116,12 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.BorLogs,
 		Default: c.cliConfig.BorLogs,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.liveness",
+		Usage:   `Enables tracking of in-turn blocks produced and slots missed by every validator`,
+		Value:   &c.cliConfig.BorLiveness,
+		Default: c.cliConfig.BorLiveness,
+	})
 
 	// logging related flags (log-level and verbosity is present above, it will be removed soon)
 	f.StringFlag(&flagset.StringFlag{
@@ -190,6 +196,72 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.Heimdall.UseHeimdallApp,
 		Default: c.cliConfig.Heimdall.UseHeimdallApp,
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdallsim",
+		Usage:   "Path to the json config of an in-process Heimdall simulator, used instead of a remote Heimdall (for devnets)",
+		Value:   &c.cliConfig.Heimdall.Simulator,
+		Default: c.cliConfig.Heimdall.Simulator,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.heimdallcache",
+		Usage:   "Persist spans, state-sync events and checkpoints fetched from Heimdall in the database and serve repeated lookups from disk",
+		Value:   &c.cliConfig.Heimdall.Cache,
+		Default: c.cliConfig.Heimdall.Cache,
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "bor.heimdallendpoints",
+		Usage:   "Comma separated list of additional Heimdall REST endpoints to fail over to",
+		Value:   &c.cliConfig.Heimdall.Endpoints,
+		Default: c.cliConfig.Heimdall.Endpoints,
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "bor.heimdallgRPCendpoints",
+		Usage:   "Comma separated list of additional Heimdall gRPC endpoints to fail over to",
+		Value:   &c.cliConfig.Heimdall.GRPCEndpoints,
+		Default: c.cliConfig.Heimdall.GRPCEndpoints,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.heimdallcrosscheck",
+		Usage:   "Confirm span and milestone responses with a second Heimdall endpoint",
+		Value:   &c.cliConfig.Heimdall.CrossCheck,
+		Default: c.cliConfig.Heimdall.CrossCheck,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.heimdallverify",
+		Usage:   "Verify spans, checkpoints and milestones with light client proofs from Heimdall's tendermint node",
+		Value:   &c.cliConfig.Heimdall.Verify,
+		Default: c.cliConfig.Heimdall.Verify,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdalltendermint",
+		Usage:   "URL of the tendermint RPC of the Heimdall node, used by bor.heimdallverify",
+		Value:   &c.cliConfig.Heimdall.TendermintURL,
+		Default: c.cliConfig.Heimdall.TendermintURL,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "bor.heimdalltrustedheight",
+		Usage:   "Height of the Heimdall header trusted on the first start of bor.heimdallverify",
+		Value:   &c.cliConfig.Heimdall.TrustedHeight,
+		Default: c.cliConfig.Heimdall.TrustedHeight,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdalltrustedhash",
+		Usage:   "Hex encoded hash of the Heimdall header trusted on the first start of bor.heimdallverify",
+		Value:   &c.cliConfig.Heimdall.TrustedHash,
+		Default: c.cliConfig.Heimdall.TrustedHash,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.heimdallbootstrap",
+		Usage:   "Sync a new node from the latest Heimdall checkpoint, backfilling the blocks before it, rather than from genesis",
+		Value:   &c.cliConfig.Heimdall.Bootstrap,
+		Default: c.cliConfig.Heimdall.Bootstrap,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "bor.heimdallbootstrapverify",
+		Usage:   "Number of checkpoints preceding the bootstrap checkpoint whose root hashes are verified against the backfilled blocks before the legacy sync is enabled, 0 for all. The older checkpoints are verified in the background afterwards",
+		Value:   &c.cliConfig.Heimdall.BootstrapVerify,
+		Default: c.cliConfig.Heimdall.BootstrapVerify,
+	})
 
 	// txpool options
 	f.SliceStringFlag(&flagset.SliceStringFlag{
@@ -269,6 +341,13 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.TxPool.LifeTime,
 		Group:   "Transaction Pool",
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "txpool.conditionalrelay",
+		Usage:   "Relay the conditional transactions to the trusted peers over the condtx protocol",
+		Value:   &c.cliConfig.TxPool.ConditionalRelay,
+		Default: c.cliConfig.TxPool.ConditionalRelay,
+		Group:   "Transaction Pool",
+	})
 
 	// sealer options
 	f.BoolFlag(&flagset.BoolFlag{
@@ -320,6 +399,20 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.Sealer.CommitInterruptFlag,
 		Group:   "Sealer",
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "miner.parallelbuild",
+		Usage:   "Execute the transactions of the mined blocks in parallel with block-stm",
+		Value:   &c.cliConfig.Sealer.ParallelBuild,
+		Default: c.cliConfig.Sealer.ParallelBuild,
+		Group:   "Sealer",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "miner.signer",
+		Usage:   "External signer (url or path to ipc file) sealing the blocks instead of the local keystore",
+		Value:   &c.cliConfig.Sealer.Signer.Endpoint,
+		Default: c.cliConfig.Sealer.Signer.Endpoint,
+		Group:   "Sealer",
+	})
 
 	// ethstats
 	f.StringFlag(&flagset.StringFlag{
@@ -505,6 +598,20 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.JsonRPC.TxFeeCap,
 		Group:   "JsonRPC",
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "rpc.safeblock",
+		Usage:   "Policy the 'safe' block tag is resolved with: the last whitelisted 'milestone' or 'checkpoint'",
+		Value:   &c.cliConfig.JsonRPC.SafeBlock,
+		Default: c.cliConfig.JsonRPC.SafeBlock,
+		Group:   "JsonRPC",
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "rpc.safeblockconfirmations",
+		Usage:   "Number of confirmations after which a block is safe with the milestone policy, even if no milestone covers it yet (0 = disabled)",
+		Value:   &c.cliConfig.JsonRPC.SafeBlockConfirmations,
+		Default: c.cliConfig.JsonRPC.SafeBlockConfirmations,
+		Group:   "JsonRPC",
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "rpc.allow-unprotected-txs",
 		Usage:   "Allow for unprotected (non EIP155 signed) transactions to be submitted via RPC",
@@ -966,6 +1073,12 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.ParallelEVM.SpeculativeProcesses,
 		Default: c.cliConfig.ParallelEVM.SpeculativeProcesses,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "parallelevm.adaptive",
+		Usage:   "Schedule the transactions in Block STM from the conflicts of the recent blocks",
+		Value:   &c.cliConfig.ParallelEVM.AdaptiveScheduling,
+		Default: c.cliConfig.ParallelEVM.AdaptiveScheduling,
+	})
 	f.Uint64Flag(&flagset.Uint64Flag{
 		Name:    "dev.gaslimit",
 		Usage:   "Initial block gas limit",
@@ -23,6 +23,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/consensus/beacon" //nolint:typecheck
@@ -233,16 +234,28 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 				authorized = true
 			}
 
-			// Authorize the bor consensus (if chosen) to sign using wallet signer
+			// Authorize the bor consensus (if chosen) to sign using the external or wallet signer
 			if bor, ok := srv.backend.Engine().(*bor.Bor); ok {
-				wallet, err := accountManager.Find(accounts.Account{Address: eb})
-				if wallet == nil || err != nil {
-					log.Error("Etherbase account unavailable locally", "err", err)
-					return nil, fmt.Errorf("signer missing: %v", err)
+				if endpoint := config.Sealer.Signer.Endpoint; endpoint != "" {
+					signer, err := external.NewExternalSigner(endpoint)
+					if err != nil {
+						log.Error("Failed to connect to external signer", "endpoint", endpoint, "err", err)
+						return nil, fmt.Errorf("external signer unavailable: %v", err)
+					}
+
+					log.Info("Sealing blocks with external signer", "endpoint", endpoint, "etherbase", eb)
+
+					bor.Authorize(eb, signer.SignData)
+				} else {
+					wallet, err := accountManager.Find(accounts.Account{Address: eb})
+					if wallet == nil || err != nil {
+						log.Error("Etherbase account unavailable locally", "err", err)
+						return nil, fmt.Errorf("signer missing: %v", err)
+					}
+
+					bor.Authorize(eb, wallet.SignData)
 				}
 
-				bor.Authorize(eb, wallet.SignData)
-
 				authorized = true
 			}
 		}
@@ -0,0 +1,260 @@
+// Slashing protection related commands
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server"
+	"github.com/ethereum/go-ethereum/node"
+
+	"github.com/mitchellh/cli"
+)
+
+// SlashingProtectionCommand is the command to group the slashing protection commands
+type SlashingProtectionCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *SlashingProtectionCommand) MarkDown() string {
+	items := []string{
+		"# slashing-protection",
+		"The ```slashing-protection``` command groups actions on the records of the headers sealed by the block producer:",
+		"- [```slashing-protection export```](./slashing-protection_export.md): Export the sealed headers to a JSON interchange file.",
+		"- [```slashing-protection import```](./slashing-protection_import.md): Import the sealed headers from a JSON interchange file.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *SlashingProtectionCommand) Help() string {
+	return `Usage: bor slashing-protection <subcommand>
+
+  This command groups actions on the records of the headers sealed by the block producer.
+
+  Export the sealed headers:
+
+    $ bor slashing-protection export --datadir <datadir> --file <file>
+
+  Import the sealed headers:
+
+    $ bor slashing-protection import --datadir <datadir> --file <file>`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *SlashingProtectionCommand) Synopsis() string {
+	return "Slashing protection related commands"
+}
+
+// Run implements the cli.Command interface
+func (c *SlashingProtectionCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// slashingProtectionFlags are the flags shared by the import and export commands
+type slashingProtectionFlags struct {
+	*Meta
+
+	datadirAncient string
+	file           string
+}
+
+func (c *slashingProtectionFlags) flags(name string, usage string) *flagset.Flagset {
+	flags := c.NewFlagSet(name)
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "datadir.ancient",
+		Value:   &c.datadirAncient,
+		Usage:   "Path of the ancient data directory to store information",
+		Default: "",
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "file",
+		Value: &c.file,
+		Usage: usage,
+	})
+
+	return flags
+}
+
+// openDatabase opens the chain database of the stopped node holding the records.
+func (c *slashingProtectionFlags) openDatabase(readonly bool) (ethdb.Database, error) {
+	datadir := c.dataDir
+	if datadir == "" {
+		datadir = server.DefaultDataDir()
+	}
+
+	stack, err := node.New(&node.Config{
+		DataDir: datadir,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dbHandles, err := server.MakeDatabaseHandles(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return stack.OpenDatabaseWithFreezer(chaindataPath, 16, dbHandles, c.datadirAncient, "", readonly, rawdb.ExtraDBConfig{})
+}
+
+// SlashingProtectionExportCommand exports the sealed headers
+type SlashingProtectionExportCommand struct {
+	slashingProtectionFlags
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *SlashingProtectionExportCommand) MarkDown() string {
+	items := []string{
+		"# Slashing protection export",
+		"The ```bor slashing-protection export``` command exports the headers sealed by the block producer to a JSON interchange file, to be imported on another host before it starts producing blocks. The node must be stopped.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *SlashingProtectionExportCommand) Help() string {
+	return `Usage: bor slashing-protection export --datadir <datadir> --file <file>
+
+  This command exports the headers sealed by the block producer to a JSON interchange file` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *SlashingProtectionExportCommand) Synopsis() string {
+	return "Export the sealed headers"
+}
+
+// Flags: datadir, datadir.ancient, file
+func (c *SlashingProtectionExportCommand) Flags() *flagset.Flagset {
+	return c.flags("slashing-protection export", "Path of the file to export to (default = stdout)")
+}
+
+// Run implements the cli.Command interface
+func (c *SlashingProtectionExportCommand) Run(args []string) int {
+	flags := c.Flags()
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	db, err := c.openDatabase(true)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer db.Close()
+
+	// The records don't depend on the fork configuration
+	interchange := bor.NewSlashingProtection(nil, db).Export()
+
+	data, err := json.MarshalIndent(interchange, "", "  ")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.file == "" {
+		c.UI.Output(string(data))
+		return 0
+	}
+
+	if err := os.WriteFile(c.file, data, 0600); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Info("Exported sealed headers to " + c.file)
+
+	return 0
+}
+
+// SlashingProtectionImportCommand imports the sealed headers
+type SlashingProtectionImportCommand struct {
+	slashingProtectionFlags
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *SlashingProtectionImportCommand) MarkDown() string {
+	items := []string{
+		"# Slashing protection import",
+		"The ```bor slashing-protection import``` command imports the headers sealed by a block producer from a JSON interchange file. Headers conflicting with the recorded ones are skipped. The node must be stopped.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *SlashingProtectionImportCommand) Help() string {
+	return `Usage: bor slashing-protection import --datadir <datadir> --file <file>
+
+  This command imports the headers sealed by a block producer from a JSON interchange file` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *SlashingProtectionImportCommand) Synopsis() string {
+	return "Import the sealed headers"
+}
+
+// Flags: datadir, datadir.ancient, file
+func (c *SlashingProtectionImportCommand) Flags() *flagset.Flagset {
+	return c.flags("slashing-protection import", "Path of the file to import from")
+}
+
+// Run implements the cli.Command interface
+func (c *SlashingProtectionImportCommand) Run(args []string) int {
+	flags := c.Flags()
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.file == "" {
+		c.UI.Error("file is required")
+		return 1
+	}
+
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	interchange := new(bor.SlashingProtectionInterchange)
+	if err := json.Unmarshal(data, interchange); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	db, err := c.openDatabase(false)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer db.Close()
+
+	imported, err := bor.NewSlashingProtection(nil, db).Import(interchange)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output("Imported sealed headers: " + strconv.Itoa(imported))
+
+	return 0
+}
@@ -55,6 +55,66 @@ web3._extend({
 			call: 'bor_getCurrentValidators',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'getSpan',
+			call: 'bor_getSpan',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getSpanByBlock',
+			call: 'bor_getSpanByBlock',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getValidatorSetHistory',
+			call: 'bor_getValidatorSetHistory',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getProducerSchedule',
+			call: 'bor_getProducerSchedule',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getValidatorPerformance',
+			call: 'bor_getValidatorPerformance',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'getDoubleSignEvidence',
+			call: 'bor_getDoubleSignEvidence',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getStateSyncById',
+			call: 'bor_getStateSyncById',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getStateSyncsByRange',
+			call: 'bor_getStateSyncsByRange',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'getStateSyncByL1TxHash',
+			call: 'bor_getStateSyncByL1TxHash',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getFinality',
+			call: 'bor_getFinality',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getMilestoneVotes',
+			call: 'bor_getMilestoneVotes',
+			params: 2
+		}),
 		new web3._extend.Method({
 			name: 'getRootHash',
 			call: 'bor_getRootHash',
@@ -514,6 +514,23 @@ web3._extend({
 			params: 2,
 			inputFormatter:[web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'parallelExecutionReport',
+			call: 'debug_parallelExecutionReport',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'txDependencyStats',
+			call: 'debug_txDependencyStats',
+			params: 0,
+		}),
+		new web3._extend.Method({
+			name: 'simulateStateSync',
+			call: 'debug_simulateStateSync',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'getWhitelistedCheckpoint',
 			call: 'debug_getWhitelistedCheckpoint',
@@ -56,6 +56,7 @@ type Config struct {
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
 	Noverify            bool           // Disable remote mining solution verification(only useful in ethash).
 	CommitInterruptFlag bool           // Interrupt commit when time is up ( default = true)
+	ParallelBuild       bool           // Execute the transactions of the mined blocks in parallel
 
 	NewPayloadTimeout time.Duration // The maximum time allowance for creating a new payload
 }
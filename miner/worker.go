@@ -1049,6 +1049,10 @@ func (w *worker) commitTransaction(env *environment, tx *types.Transaction, inte
 
 //nolint:gocognit
 func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByPriceAndNonce, interrupt *atomic.Int32, interruptCtx context.Context) error {
+	if w.config.ParallelBuild {
+		return w.commitTransactionsParallel(env, txs, interrupt, interruptCtx)
+	}
+
 	gasLimit := env.header.GasLimit
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
@@ -1242,85 +1246,101 @@ mainloop:
 		}
 	}
 
-	// nolint:nestif
 	if EnableMVHashMap && w.IsRunning() {
 		close(chDeps)
 		depsWg.Wait()
 
-		var blockExtraData types.BlockExtraData
+		if err := w.setTxDependency(env, deps, mvReadMapList); err != nil {
+			return err
+		}
+	}
 
-		tempVanity := env.header.Extra[:types.ExtraVanityLength]
-		tempSeal := env.header.Extra[len(env.header.Extra)-types.ExtraSealLength:]
+	w.sendPendingLogs(coalescedLogs)
 
-		if len(mvReadMapList) > 0 {
-			tempDeps := make([][]uint64, len(mvReadMapList))
+	return nil
+}
 
-			for j := range deps[0] {
-				tempDeps[0] = append(tempDeps[0], uint64(j))
-			}
+// setTxDependency stores the dependencies between the transactions of the block
+// in its extra data. They're left out if a transaction reads the balance the
+// fees are paid to.
+func (w *worker) setTxDependency(env *environment, deps map[int]map[int]bool, mvReadMapList []map[blockstm.Key]blockstm.ReadDescriptor) error {
+	var blockExtraData types.BlockExtraData
 
-			delayFlag := true
+	tempVanity := env.header.Extra[:types.ExtraVanityLength]
+	tempSeal := env.header.Extra[len(env.header.Extra)-types.ExtraSealLength:]
 
-			for i := 1; i <= len(mvReadMapList)-1; i++ {
-				reads := mvReadMapList[i-1]
+	if len(mvReadMapList) > 0 {
+		tempDeps := make([][]uint64, len(mvReadMapList))
 
-				_, ok1 := reads[blockstm.NewSubpathKey(env.coinbase, state.BalancePath)]
-				_, ok2 := reads[blockstm.NewSubpathKey(common.HexToAddress(w.chainConfig.Bor.CalculateBurntContract(env.header.Number.Uint64())), state.BalancePath)]
+		for j := range deps[0] {
+			tempDeps[0] = append(tempDeps[0], uint64(j))
+		}
 
-				if ok1 || ok2 {
-					delayFlag = false
-				}
+		delayFlag := true
 
-				for j := range deps[i] {
-					tempDeps[i] = append(tempDeps[i], uint64(j))
-				}
-			}
+		for i := 1; i <= len(mvReadMapList)-1; i++ {
+			reads := mvReadMapList[i-1]
+
+			_, ok1 := reads[blockstm.NewSubpathKey(env.coinbase, state.BalancePath)]
+			_, ok2 := reads[blockstm.NewSubpathKey(common.HexToAddress(w.chainConfig.Bor.CalculateBurntContract(env.header.Number.Uint64())), state.BalancePath)]
 
-			if err := rlp.DecodeBytes(env.header.Extra[types.ExtraVanityLength:len(env.header.Extra)-types.ExtraSealLength], &blockExtraData); err != nil {
-				log.Error("error while decoding block extra data", "err", err)
-				return err
+			if ok1 || ok2 {
+				delayFlag = false
 			}
 
-			if delayFlag {
-				blockExtraData.TxDependency = tempDeps
-			} else {
-				blockExtraData.TxDependency = nil
+			for j := range deps[i] {
+				tempDeps[i] = append(tempDeps[i], uint64(j))
 			}
-		} else {
-			blockExtraData.TxDependency = nil
 		}
 
-		blockExtraDataBytes, err := rlp.EncodeToBytes(blockExtraData)
-		if err != nil {
-			log.Error("error while encoding block extra data: %v", err)
+		if err := rlp.DecodeBytes(env.header.Extra[types.ExtraVanityLength:len(env.header.Extra)-types.ExtraSealLength], &blockExtraData); err != nil {
+			log.Error("error while decoding block extra data", "err", err)
 			return err
 		}
 
-		env.header.Extra = []byte{}
+		if delayFlag {
+			blockExtraData.TxDependency = tempDeps
+		} else {
+			blockExtraData.TxDependency = nil
+		}
+	} else {
+		blockExtraData.TxDependency = nil
+	}
 
-		env.header.Extra = append(tempVanity, blockExtraDataBytes...)
+	blockExtraDataBytes, err := rlp.EncodeToBytes(blockExtraData)
+	if err != nil {
+		log.Error("error while encoding block extra data: %v", err)
+		return err
+	}
 
-		env.header.Extra = append(env.header.Extra, tempSeal...)
+	env.header.Extra = []byte{}
 
-	}
+	env.header.Extra = append(tempVanity, blockExtraDataBytes...)
 
-	if !w.IsRunning() && len(coalescedLogs) > 0 {
-		// We don't push the pendingLogsEvent while we are sealing. The reason is that
-		// when we are sealing, the worker will regenerate a sealing block every 3 seconds.
-		// In order to avoid pushing the repeated pendingLog, we disable the pending log pushing.
-		// make a copy, the state caches the logs and these logs get "upgraded" from pending to mined
-		// logs by filling in the block hash when the block was mined by the local miner. This can
-		// cause a race condition if a log was "upgraded" before the PendingLogsEvent is processed.
-		cpy := make([]*types.Log, len(coalescedLogs))
-		for i, l := range coalescedLogs {
-			cpy[i] = new(types.Log)
-			*cpy[i] = *l
-		}
+	env.header.Extra = append(env.header.Extra, tempSeal...)
+
+	return nil
+}
 
-		w.pendingLogsFeed.Send(cpy)
+// sendPendingLogs sends the logs of the pending block to the subscribers.
+func (w *worker) sendPendingLogs(coalescedLogs []*types.Log) {
+	if w.IsRunning() || len(coalescedLogs) == 0 {
+		return
 	}
 
-	return nil
+	// We don't push the pendingLogsEvent while we are sealing. The reason is that
+	// when we are sealing, the worker will regenerate a sealing block every 3 seconds.
+	// In order to avoid pushing the repeated pendingLog, we disable the pending log pushing.
+	// make a copy, the state caches the logs and these logs get "upgraded" from pending to mined
+	// logs by filling in the block hash when the block was mined by the local miner. This can
+	// cause a race condition if a log was "upgraded" before the PendingLogsEvent is processed.
+	cpy := make([]*types.Log, len(coalescedLogs))
+	for i, l := range coalescedLogs {
+		cpy[i] = new(types.Log)
+		*cpy[i] = *l
+	}
+
+	w.pendingLogsFeed.Send(cpy)
 }
 
 // generateParams wraps various of settings for generating sealing task.
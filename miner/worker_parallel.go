@@ -0,0 +1,285 @@
+package miner
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/blockstm"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// parallelBuildBatchSize is the maximum number of transactions executed
+// together when building blocks in parallel.
+const parallelBuildBatchSize = 128
+
+var (
+	parallelBuildBatchMeter    = metrics.NewRegisteredMeter("worker/parallelbuild/batches", nil)
+	parallelBuildFallbackMeter = metrics.NewRegisteredMeter("worker/parallelbuild/fallbacks", nil)
+)
+
+// commitTransactionsParallel fills the block like commitTransactions, but takes
+// the transactions by batches and executes each batch with Block-STM. The
+// transactions are committed in price and nonce order, and the dependencies
+// between them are recorded the same way.
+//
+//nolint:gocognit
+func (w *worker) commitTransactionsParallel(env *environment, txs *types.TransactionsByPriceAndNonce, interrupt *atomic.Int32, interruptCtx context.Context) error {
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+
+	var (
+		coalescedLogs []*types.Log
+
+		deps                = map[int]map[int]bool{}
+		depsMVFullWriteList [][]blockstm.WriteDescriptor
+		mvReadMapList       []map[blockstm.Key]blockstm.ReadDescriptor
+
+		// Senders whose next transactions can't be valid anymore
+		skipped = make(map[common.Address]bool)
+	)
+
+	EnableMVHashMap := w.chainConfig.Bor.IsParallelUniverse(env.header.Number)
+
+	// record adds the reads and writes of a committed transaction to the
+	// dependencies of the block
+	record := func(reads []blockstm.ReadDescriptor, writes []blockstm.WriteDescriptor) {
+		if !EnableMVHashMap {
+			return
+		}
+
+		readMap := make(map[blockstm.Key]blockstm.ReadDescriptor, len(reads))
+		for _, rd := range reads {
+			readMap[rd.Path] = rd
+		}
+
+		depsMVFullWriteList = append(depsMVFullWriteList, writes)
+		mvReadMapList = append(mvReadMapList, readMap)
+
+		deps = blockstm.UpdateDeps(deps, blockstm.TxDep{
+			Index:         len(mvReadMapList) - 1,
+			ReadList:      reads,
+			FullWriteList: depsMVFullWriteList,
+		})
+	}
+
+	// commitSerially applies the transactions of a batch which can't be executed
+	// in parallel one after the other
+	commitSerially := func(batch []*types.Transaction) {
+		for _, tx := range batch {
+			from, _ := types.Sender(env.signer, tx)
+			if skipped[from] {
+				continue
+			}
+
+			env.state.SetTxContext(tx.Hash(), env.tcount)
+
+			if EnableMVHashMap {
+				env.state.AddEmptyMVHashMap()
+			}
+
+			logs, err := w.commitTransaction(env, tx, interruptCtx)
+
+			switch {
+			case errors.Is(err, core.ErrNonceTooLow):
+				log.Trace("Skipping transaction with low nonce", "sender", from, "nonce", tx.Nonce())
+
+			case err == nil:
+				coalescedLogs = append(coalescedLogs, logs...)
+				env.tcount++
+
+				if EnableMVHashMap {
+					record(env.state.MVReadList(), env.state.MVFullWriteList())
+				}
+
+			default:
+				log.Debug("Transaction failed, account skipped", "hash", tx.Hash(), "err", err)
+
+				skipped[from] = true
+			}
+
+			if EnableMVHashMap {
+				env.state.SetMVHashmap(nil)
+			}
+		}
+	}
+
+mainloop:
+	for {
+		// case of interrupting by timeout
+		if interruptCtx != nil {
+			select {
+			case <-interruptCtx.Done():
+				txCommitInterruptCounter.Inc(1)
+				log.Warn("Tx Level Interrupt")
+				break mainloop
+			default:
+			}
+		}
+
+		// Check interruption signal and abort building if it's fired.
+		if interrupt != nil {
+			if signal := interrupt.Load(); signal != commitInterruptNone {
+				return signalToErr(signal)
+			}
+		}
+
+		// If we don't have enough gas for any further transactions then we're done.
+		if env.gasPool.Gas() < params.TxGas {
+			log.Trace("Not enough gas for further transactions", "have", env.gasPool, "want", params.TxGas)
+			break
+		}
+
+		batch := w.nextParallelBatch(env, txs, skipped)
+		if len(batch) == 0 {
+			break
+		}
+
+		parallelBuildBatchMeter.Mark(1)
+
+		res, err := core.ApplyTransactionsParallel(w.chainConfig, w.chain, env.coinbase, env.state, env.header, env.receipts, batch, *w.chain.GetVMConfig(), interruptCtx)
+		if err != nil {
+			if interruptCtx != nil && interruptCtx.Err() != nil {
+				txCommitInterruptCounter.Inc(1)
+				log.Warn("Tx Level Interrupt")
+
+				break
+			}
+
+			if !errors.Is(err, core.ErrParallelFeeDelay) {
+				log.Warn("Failed to execute transactions in parallel", "err", err)
+			}
+
+			parallelBuildFallbackMeter.Mark(1)
+			commitSerially(batch)
+
+			continue
+		}
+
+		for i, tx := range batch {
+			if err := res.Errors[i]; err != nil {
+				from, _ := types.Sender(env.signer, tx)
+
+				// Transactions with a low nonce are only stale, the next ones may be valid
+				if !errors.Is(err, core.ErrNonceTooLow) {
+					log.Debug("Transaction failed, account skipped", "hash", tx.Hash(), "err", err)
+
+					skipped[from] = true
+				}
+
+				continue
+			}
+
+			env.txs = append(env.txs, tx)
+			env.tcount++
+
+			record(res.ReadLists[i], res.FullWriteLists[i])
+		}
+
+		for _, receipt := range res.Receipts[len(env.receipts):] {
+			coalescedLogs = append(coalescedLogs, receipt.Logs...)
+		}
+
+		env.gasPool.SetGas(env.gasPool.Gas() - (res.GasUsed - env.header.GasUsed))
+		env.header.GasUsed = res.GasUsed
+		env.receipts = res.Receipts
+
+		env.state.StopPrefetcher()
+		env.state = res.State
+	}
+
+	if EnableMVHashMap && w.IsRunning() {
+		if err := w.setTxDependency(env, deps, mvReadMapList); err != nil {
+			return err
+		}
+	}
+
+	w.sendPendingLogs(coalescedLogs)
+
+	return nil
+}
+
+// nextParallelBatch takes the next transactions to execute together, in price
+// and nonce order, as long as their gas limits fit in the block.
+func (w *worker) nextParallelBatch(env *environment, txs *types.TransactionsByPriceAndNonce, skipped map[common.Address]bool) []*types.Transaction {
+	var (
+		batch []*types.Transaction
+		gas   uint64
+	)
+
+	for len(batch) < parallelBuildBatchSize {
+		tx := txs.Peek()
+		if tx == nil {
+			break
+		}
+
+		from, _ := types.Sender(env.signer, tx)
+		if skipped[from] {
+			txs.Pop()
+			continue
+		}
+
+		// Conditions are checked against the state the transaction is applied on,
+		// conditional transactions must come first in their batch
+		if options := tx.GetOptions(); options != nil {
+			if len(batch) > 0 {
+				break
+			}
+
+			if err := env.header.ValidateBlockNumberOptions4337(options.BlockNumberMin, options.BlockNumberMax); err != nil {
+				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Hash(), "reason", err)
+				txs.Pop()
+
+				continue
+			}
+
+			if err := env.header.ValidateTimestampOptions4337(options.TimestampMin, options.TimestampMax); err != nil {
+				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Hash(), "reason", err)
+				txs.Pop()
+
+				continue
+			}
+
+			if err := env.state.ValidateKnownAccounts(options.KnownAccounts); err != nil {
+				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Hash(), "reason", err)
+				txs.Pop()
+
+				continue
+			}
+		}
+
+		// Check whether the tx is replay protected. If we're not in the EIP155 hf
+		// phase, start ignoring the sender until we do.
+		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
+			log.Trace("Ignoring reply protected transaction", "hash", tx.Hash(), "eip155", w.chainConfig.EIP155Block)
+			txs.Pop()
+
+			continue
+		}
+
+		if gas+tx.Gas() > env.gasPool.Gas() {
+			// The transaction may fit once the gas actually used by the batch is known
+			if len(batch) > 0 {
+				break
+			}
+
+			log.Trace("Skipping transaction exceeding the gas left", "hash", tx.Hash(), "gas", tx.Gas(), "left", env.gasPool.Gas())
+			txs.Pop()
+
+			continue
+		}
+
+		batch = append(batch, tx)
+		gas += tx.Gas()
+
+		txs.Shift()
+	}
+
+	return batch
+}
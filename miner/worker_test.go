@@ -205,6 +205,116 @@ func testGenerateBlockAndImport(t *testing.T, isClique bool, isBor bool) {
 	}
 }
 
+// nolint : paralleltest
+func TestGenerateBlockAndImportBorParallel(t *testing.T) {
+	chainConfig := *params.BorUnittestChainConfig
+
+	// The importer verifies the declared dependencies
+	borConfig := *chainConfig.Bor
+	borConfig.TxDependencyCheckBlock = big.NewInt(0)
+	chainConfig.Bor = &borConfig
+
+	engine, ctrl := getFakeBorFromConfig(t, &chainConfig)
+	defer ctrl.Finish()
+	defer engine.Close()
+
+	db := rawdb.NewMemoryDatabase()
+
+	w, b, _ := newTestWorker(t, &chainConfig, engine, db, 0, false, 0, 0)
+	defer w.close()
+
+	config := *w.config
+	config.ParallelBuild = true
+	w.config = &config
+
+	// The blocks are late, don't interrupt their building
+	w.interruptCommitFlag = false
+
+	// This test chain imports the mined blocks, using their dependencies.
+	chain, _ := core.NewParallelBlockChain(rawdb.NewMemoryDatabase(), core.DefaultCacheConfig, b.Genesis, nil, engine, vm.Config{ParallelEnable: true, ParallelSpeculativeProcesses: 8}, nil, nil, nil)
+	defer chain.Stop()
+
+	// Ignore empty commit here for less noise.
+	w.skipSealHook = func(task *task) bool {
+		return len(task.receipts) == 0
+	}
+
+	// Wait for mined blocks.
+	sub := w.mux.Subscribe(core.NewMinedBlockEvent{})
+	defer sub.Unsubscribe()
+
+	// The block producer reads its own balance when sending transactions, which
+	// drops the dependencies of the block, so the transactions are sent by a
+	// funded user instead.
+	var (
+		gasPrice = big.NewInt(10 * params.InitialBaseFee)
+		to       = common.HexToAddress("0xdead")
+		nonce    uint64
+	)
+
+	funding, _ := types.SignTx(types.NewTransaction(b.txPool.Nonce(TestBankAddress), testUserAddress, big.NewInt(params.Ether), params.TxGas, gasPrice, nil), types.HomesteadSigner{}, testBankKey)
+	if err := b.txPool.AddLocal(funding); err != nil {
+		t.Fatal("while adding the funding transaction", err)
+	}
+
+	// Start mining!
+	w.start()
+
+	for i := 0; i < 8; i++ {
+		if i > 0 {
+			// Wait for the pool to see the funds of the user
+			for pending, _ := b.txPool.Stats(); pending > 0; pending, _ = b.txPool.Stats() {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			for j := 0; j < 3; j++ {
+				tx, _ := types.SignTx(types.NewTransaction(nonce, to, big.NewInt(1000), params.TxGas, gasPrice, nil), types.HomesteadSigner{}, testUserKey)
+				if err := b.txPool.AddLocal(tx); err != nil {
+					t.Fatal("while adding a local transaction", err)
+				}
+
+				nonce++
+			}
+		}
+
+		select {
+		case ev := <-sub.Chan():
+			block := ev.Data.(core.NewMinedBlockEvent).Block
+			if _, err := chain.InsertChain([]*types.Block{block}); err != nil {
+				t.Fatalf("failed to insert new mined block %d: %v", block.NumberU64(), err)
+			}
+
+			if !chainConfig.Bor.IsParallelUniverse(block.Number()) {
+				continue
+			}
+
+			// All the transactions are sent by the same account
+			deps := block.GetTxDependency()
+			if len(deps) != block.Transactions().Len() {
+				t.Fatalf("block %d: have %d dependencies, want %d", block.NumberU64(), len(deps), block.Transactions().Len())
+			}
+
+			for i := 1; i < len(deps); i++ {
+				if len(deps[i]) != 1 || deps[i][0] != uint64(i-1) {
+					t.Fatalf("block %d: wrong dependencies of transaction %d: %v", block.NumberU64(), i, deps[i])
+				}
+			}
+		case <-time.After(3 * time.Second): // Worker needs 1s to include new changes.
+			t.Fatalf("timeout")
+		}
+	}
+
+	if have := chain.CurrentBlock().Number.Uint64(); have < 5 {
+		t.Fatalf("only %d blocks mined", have)
+	}
+
+	// The declared dependencies match the ones observed
+	stats := chain.TxDependencyStats()[TestBankAddress]
+	if stats.Blocks == 0 || stats.Mismatches != 0 {
+		t.Fatalf("wrong verification of the dependencies: %+v", stats)
+	}
+}
+
 func getFakeBorFromConfig(t *testing.T, chainConfig *params.ChainConfig) (consensus.Engine, *gomock.Controller) {
 	t.Helper()
 
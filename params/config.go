@@ -708,6 +708,10 @@ type BorConfig struct {
 	ParallelUniverseBlock      *big.Int               `json:"parallelUniverseBlock"`      // TODO: update all occurrence, change name and finalize number (hardfork for block-stm related changes)
 	IndoreBlock                *big.Int               `json:"indoreBlock"`                // Indore switch block (nil = no fork, 0 = already on indore)
 	StateSyncConfirmationDelay map[string]uint64      `json:"stateSyncConfirmationDelay"` // StateSync Confirmation Delay, in seconds, to calculate `to`
+	TxDependencyCheckBlock     *big.Int               `json:"txDependencyCheckBlock"`     // Blocks whose declared transaction dependencies miss observed ones are rejected from this block (nil = no fork)
+	StateSyncBudgetBlock       *big.Int               `json:"stateSyncBudgetBlock"`       // The state syncs committed per sprint are limited from this block, the remainder is carried over (nil = no fork)
+	StateSyncGasBudget         map[string]uint64      `json:"stateSyncGasBudget"`         // Gas the state syncs committed per sprint may use, once reached no other one is committed (0 = unlimited)
+	StateSyncRecordBudget      map[string]uint64      `json:"stateSyncRecordBudget"`      // Maximum number of state syncs committed per sprint (0 = unlimited)
 }
 
 // String implements the stringer interface, returning the consensus engine details.
@@ -743,10 +747,38 @@ func (c *BorConfig) IsIndore(number *big.Int) bool {
 	return isBlockForked(c.IndoreBlock, number)
 }
 
+func (c *BorConfig) IsTxDependencyCheck(number *big.Int) bool {
+	return isBlockForked(c.TxDependencyCheckBlock, number)
+}
+
 func (c *BorConfig) CalculateStateSyncDelay(number uint64) uint64 {
 	return borKeyValueConfigHelper(c.StateSyncConfirmationDelay, number)
 }
 
+func (c *BorConfig) IsStateSyncBudget(number *big.Int) bool {
+	return isBlockForked(c.StateSyncBudgetBlock, number)
+}
+
+// CalculateStateSyncGasBudget returns the gas the state syncs committed in the
+// sprint starting at number may use, 0 if unlimited.
+func (c *BorConfig) CalculateStateSyncGasBudget(number uint64) uint64 {
+	if len(c.StateSyncGasBudget) == 0 {
+		return 0
+	}
+
+	return borKeyValueConfigHelper(c.StateSyncGasBudget, number)
+}
+
+// CalculateStateSyncRecordBudget returns the maximum number of state syncs
+// committed in the sprint starting at number, 0 if unlimited.
+func (c *BorConfig) CalculateStateSyncRecordBudget(number uint64) uint64 {
+	if len(c.StateSyncRecordBudget) == 0 {
+		return 0
+	}
+
+	return borKeyValueConfigHelper(c.StateSyncRecordBudget, number)
+}
+
 // TODO: modify this function once the block number is finalized
 func (c *BorConfig) IsParallelUniverse(number *big.Int) bool {
 	if c.ParallelUniverseBlock != nil {
@@ -75,6 +75,16 @@ func (l *AuditLogger) SignData(ctx context.Context, contentType string, addr com
 	return b, e
 }
 
+func (l *AuditLogger) SignBorHeader(ctx context.Context, addr common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	l.log.Info("SignBorHeader", "type", "request", "metadata", MetadataFromContext(ctx).String(),
+		"addr", addr.String(), "data", data)
+
+	b, e := l.api.SignBorHeader(ctx, addr, data)
+	l.log.Info("SignBorHeader", "type", "response", "data", common.Bytes2Hex(b), "error", e)
+
+	return b, e
+}
+
 func (l *AuditLogger) SignGnosisSafeTx(ctx context.Context, addr common.MixedcaseAddress, gnosisTx GnosisSafeTx, methodSelector *string) (*GnosisSafeTx, error) {
 	sel := "<nil>"
 	if methodSelector != nil {
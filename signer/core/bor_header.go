@@ -0,0 +1,193 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/ethereum/go-ethereum/signer/storage"
+)
+
+var (
+	// ErrBorHeaderDoubleSign is returned when asked to sign a bor header at a
+	// height where a different header was signed already.
+	ErrBorHeaderDoubleSign = errors.New("refusing to sign a second bor header at the same height")
+
+	// ErrBorHeaderTooOld is returned when asked to sign a bor header below the
+	// highest height signed so far.
+	ErrBorHeaderTooOld = errors.New("refusing to sign a bor header below the last signed height")
+)
+
+// borHeaderKeyPrefix + signer address -> json encoded signedBorHeader
+const borHeaderKeyPrefix = "bor-header-"
+
+// signedBorHeader is the last header signed by an account.
+type signedBorHeader struct {
+	Number   uint64      `json:"number"`
+	SealHash common.Hash `json:"sealHash"`
+}
+
+// borHeaderGuard keeps track of the highest bor header signed by every account
+// and refuses to sign conflicting headers. A header is only signed if it's
+// above the highest signed one, or is that very same header.
+type borHeaderGuard struct {
+	lock    sync.Mutex
+	storage storage.Storage
+}
+
+func newBorHeaderGuard(storage storage.Storage) *borHeaderGuard {
+	return &borHeaderGuard{storage: storage}
+}
+
+func (g *borHeaderGuard) last(signer common.Address) (*signedBorHeader, error) {
+	data, err := g.storage.Get(borHeaderKeyPrefix + signer.Hex())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	last := new(signedBorHeader)
+	if err := json.Unmarshal([]byte(data), last); err != nil {
+		return nil, err
+	}
+
+	return last, nil
+}
+
+// sign calls signFn if the header can be signed by signer and records it as
+// the last header signed once the signature is produced. Requests are
+// serialized, so that concurrent requests can't both pass the checks.
+func (g *borHeaderGuard) sign(signer common.Address, number uint64, sealHash common.Hash, signFn func() (hexutil.Bytes, error)) (hexutil.Bytes, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	last, err := g.last(signer)
+	if err != nil {
+		// Without the record the header could conflict with an earlier one
+		return nil, fmt.Errorf("failed to read last signed bor header: %w", err)
+	}
+
+	if last != nil {
+		if number < last.Number {
+			return nil, fmt.Errorf("%w: %d < %d", ErrBorHeaderTooOld, number, last.Number)
+		}
+
+		if number == last.Number && sealHash != last.SealHash {
+			return nil, fmt.Errorf("%w: %d, signed %x, requested %x", ErrBorHeaderDoubleSign, number, last.SealHash, sealHash)
+		}
+	}
+
+	signature, err := signFn()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(&signedBorHeader{Number: number, SealHash: sealHash})
+	if err != nil {
+		return nil, err
+	}
+
+	g.storage.Put(borHeaderKeyPrefix+signer.Hex(), string(data))
+
+	return signature, nil
+}
+
+// SetBorHeaderStorage sets the storage recording the last bor header signed by
+// every account. By default the records are only kept in memory.
+func (api *SignerAPI) SetBorHeaderStorage(storage storage.Storage) {
+	api.borHeaders = newBorHeaderGuard(storage)
+}
+
+// decodeBorHeader decodes the bor sealing payload, the RLP of the header
+// without the signature in the extra data, and returns the header along with
+// its seal hash. The payload is rejected unless it's the exact encoding bor
+// would have hashed for the header.
+func decodeBorHeader(data []byte) (*types.Header, common.Hash, error) {
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(data, header); err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	if header.Number == nil || header.Difficulty == nil {
+		return nil, common.Hash{}, errors.New("bor header without number or difficulty")
+	}
+
+	// The base fee is only part of the seal hash after jaipur
+	config := &params.BorConfig{}
+	if header.BaseFee != nil {
+		config.JaipurBlock = common.Big0
+	}
+
+	// Leave room for the signature, as in a header ready to be sealed
+	sealed := types.CopyHeader(header)
+	sealed.Extra = append(sealed.Extra, make([]byte, types.ExtraSealLength)...)
+
+	// Anything else than the canonical encoding, e.g. trailing fields, is refused
+	sealHash := bor.SealHash(sealed, config)
+	if sealHash != crypto.Keccak256Hash(data) {
+		return nil, common.Hash{}, errors.New("bor header encoding doesn't match its seal hash")
+	}
+
+	return header, sealHash, nil
+}
+
+// borHeaderRequest creates the request to sign the seal hash of a bor header.
+func borHeaderRequest(data []byte) (*SignDataRequest, error) {
+	header, sealHash, err := decodeBorHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []*apitypes.NameValueType{
+		{
+			Name:  "Bor header",
+			Typ:   "bor",
+			Value: fmt.Sprintf("bor header %d [seal hash %#x]", header.Number, sealHash),
+		},
+	}
+
+	return &SignDataRequest{ContentType: apitypes.ApplicationBor.Mime, Rawdata: data, Messages: messages, Hash: sealHash.Bytes()}, nil
+}
+
+// signBorHeader signs the bor header of the request, unless a conflicting
+// header was signed before by the same account.
+func (api *SignerAPI) signBorHeader(req *SignDataRequest) (hexutil.Bytes, error) {
+	header, sealHash, err := decodeBorHeader(req.Rawdata)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := api.borHeaders.sign(req.Address.Address(), header.Number.Uint64(), sealHash, func() (hexutil.Bytes, error) {
+		// Bor uses V on the form 0 or 1
+		return api.sign(req, false)
+	})
+	if err != nil {
+		log.Warn("Bor header not signed", "signer", req.Address.Address(), "number", header.Number, "sealhash", sealHash, "err", err)
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+// SignBorHeader signs the seal hash of a bor header, given as the RLP bor signs
+// with the signature left out of the extra data. The signer never signs two
+// different headers at the same height, nor headers below the highest height
+// signed so far.
+func (api *SignerAPI) SignBorHeader(ctx context.Context, addr common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	return api.SignData(ctx, accounts.MimetypeBor, addr, hexutil.Encode(data))
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/storage"
+)
+
+func TestDecodeBorHeader(t *testing.T) {
+	config := &params.BorConfig{JaipurBlock: big.NewInt(10)}
+
+	for _, number := range []int64{5, 10} {
+		header := &types.Header{
+			Number:     big.NewInt(number),
+			Difficulty: big.NewInt(1),
+			Extra:      make([]byte, types.ExtraVanityLength+types.ExtraSealLength),
+			BaseFee:    big.NewInt(7),
+		}
+
+		decoded, sealHash, err := decodeBorHeader(bor.BorRLP(header, config))
+		if err != nil {
+			t.Fatalf("block %d: failed to decode header: %v", number, err)
+		}
+
+		if sealHash != bor.SealHash(header, config) {
+			t.Errorf("block %d: seal hash mismatch: have %x, want %x", number, sealHash, bor.SealHash(header, config))
+		}
+
+		if decoded.Number.Cmp(header.Number) != 0 {
+			t.Errorf("block %d: number mismatch: have %d", number, decoded.Number)
+		}
+	}
+
+	// Trailing fields aren't part of the seal hash, they must not be signed
+	header := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(1), Extra: []byte{}, BaseFee: big.NewInt(7), WithdrawalsHash: &common.Hash{}}
+
+	data, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := decodeBorHeader(data); err == nil {
+		t.Error("expected header with trailing fields to be refused")
+	}
+}
+
+func TestBorHeaderGuard(t *testing.T) {
+	var (
+		store  = storage.NewEphemeralStorage()
+		guard  = newBorHeaderGuard(store)
+		signer = common.HexToAddress("0x1")
+		other  = common.HexToAddress("0x2")
+		signed = 0
+	)
+
+	signFn := func() (hexutil.Bytes, error) {
+		signed++
+		return hexutil.Bytes{0x1}, nil
+	}
+
+	if _, err := guard.sign(signer, 10, common.Hash{0xa}, signFn); err != nil {
+		t.Fatalf("failed to sign first header: %v", err)
+	}
+
+	// The same header can be signed again
+	if _, err := guard.sign(signer, 10, common.Hash{0xa}, signFn); err != nil {
+		t.Fatalf("failed to sign same header again: %v", err)
+	}
+
+	if _, err := guard.sign(signer, 10, common.Hash{0xb}, signFn); !errors.Is(err, ErrBorHeaderDoubleSign) {
+		t.Fatalf("expected double sign to be refused, got %v", err)
+	}
+
+	if _, err := guard.sign(signer, 9, common.Hash{0xc}, signFn); !errors.Is(err, ErrBorHeaderTooOld) {
+		t.Fatalf("expected older header to be refused, got %v", err)
+	}
+
+	// Other signers are tracked separately
+	if _, err := guard.sign(other, 10, common.Hash{0xb}, signFn); err != nil {
+		t.Fatalf("failed to sign header of other signer: %v", err)
+	}
+
+	// Failed signatures are not recorded
+	if _, err := guard.sign(signer, 11, common.Hash{0xd}, func() (hexutil.Bytes, error) { return nil, ErrRequestDenied }); !errors.Is(err, ErrRequestDenied) {
+		t.Fatalf("expected denied request, got %v", err)
+	}
+
+	if _, err := guard.sign(signer, 11, common.Hash{0xe}, signFn); err != nil {
+		t.Fatalf("failed to sign header after denied request: %v", err)
+	}
+
+	if signed != 4 {
+		t.Errorf("signed headers mismatch: have %d, want 4", signed)
+	}
+
+	// The records survive a restart
+	guard = newBorHeaderGuard(store)
+	if _, err := guard.sign(signer, 11, common.Hash{0xd}, signFn); !errors.Is(err, ErrBorHeaderDoubleSign) {
+		t.Fatalf("expected double sign to be refused after restart, got %v", err)
+	}
+}
@@ -85,7 +85,13 @@ func (api *SignerAPI) SignData(ctx context.Context, contentType string, addr com
 		return nil, err
 	}
 
-	signature, err := api.sign(req, transformV)
+	var signature hexutil.Bytes
+	if req.ContentType == apitypes.ApplicationBor.Mime {
+		signature, err = api.signBorHeader(req)
+	} else {
+		signature, err = api.sign(req, transformV)
+	}
+
 	if err != nil {
 		api.UI.ShowError(err.Error())
 		return nil, err
@@ -175,6 +181,19 @@ func (api *SignerAPI) determineSignatureFormat(ctx context.Context, contentType
 		// Clique uses V on the form 0 or 1
 		useEthereumV = false
 		req = &SignDataRequest{ContentType: mediaType, Rawdata: cliqueRlp, Messages: messages, Hash: sighash}
+	case apitypes.ApplicationBor.Mime:
+		// Bor headers are signed with anti double sign checks
+		borData, err := fromHex(data)
+		if err != nil {
+			return nil, useEthereumV, err
+		}
+
+		req, err = borHeaderRequest(borData)
+		if err != nil {
+			return nil, useEthereumV, err
+		}
+		// Bor uses V on the form 0 or 1
+		useEthereumV = false
 	case apitypes.DataTyped.Mime:
 		// EIP-712 conformant typed data
 		var err error
@@ -347,7 +347,7 @@ func TestReorgingAfterWhitelisting(t *testing.T) {
 
 		if blockHeaderVal0.Number.Uint64() == 13 {
 			block13Hash := blockHeaderVal0.Hash()
-			nodes[0].Downloader().ChainValidator.ProcessMilestone(13, block13Hash)
+			nodes[0].Downloader().ChainValidator.ProcessMilestone(13, block13Hash, "")
 		}
 
 		if blockHeaderVal0.Number.Uint64() == 14 {
@@ -457,13 +457,13 @@ func TestPeerConnectionAfterWhitelisting(t *testing.T) {
 		//Whitelist the validator0 with milestone at 12
 		if blockHeaderVal0.Number.Uint64() == 12 {
 			block12Hash := blockHeaderVal0.Hash()
-			nodes[0].Downloader().ChainValidator.ProcessMilestone(uint64(12), block12Hash)
+			nodes[0].Downloader().ChainValidator.ProcessMilestone(uint64(12), block12Hash, "")
 		}
 
 		///Whitelist the validator1 with milestone at 12
 		if blockHeaderVal1.Number.Uint64() == 12 {
 			block12Hash := blockHeaderVal1.Hash()
-			nodes[1].Downloader().ChainValidator.ProcessMilestone(uint64(12), block12Hash)
+			nodes[1].Downloader().ChainValidator.ProcessMilestone(uint64(12), block12Hash, "")
 		}
 
 		if blockHeaderVal0.Number.Uint64() > 12 && blockHeaderVal0.Number.Uint64() > 12 {
@@ -884,12 +884,12 @@ func TestReorgingAfterWhitelistingOnDifferentHash(t *testing.T) {
 		//whitelisting at height
 		if blockHeaderVal0.Number.Uint64() == 1 {
 			block1Hash := blockHeaderVal0.Hash()
-			nodes[0].Downloader().ChainValidator.ProcessMilestone(uint64(1), block1Hash)
+			nodes[0].Downloader().ChainValidator.ProcessMilestone(uint64(1), block1Hash, "")
 		}
 
 		if blockHeaderVal1.Number.Uint64() == 1 {
 			block1Hash := blockHeaderVal1.Hash()
-			nodes[1].Downloader().ChainValidator.ProcessMilestone(uint64(1), block1Hash)
+			nodes[1].Downloader().ChainValidator.ProcessMilestone(uint64(1), block1Hash, "")
 		}
 
 		if blockHeaderVal0.Number.Uint64() > 1 && blockHeaderVal1.Number.Uint64() > 1 {
@@ -987,19 +987,19 @@ func TestNonMinerNodeWithWhitelisting(t *testing.T) {
 		//Whitelisting milestone
 		if blockHeaderVal1.Number.Uint64() == 7 {
 			blockHash := blockHeaderVal1.Hash()
-			nodes[0].Downloader().ChainValidator.ProcessMilestone(blockHeaderVal1.Number.Uint64(), blockHash)
+			nodes[0].Downloader().ChainValidator.ProcessMilestone(blockHeaderVal1.Number.Uint64(), blockHash, "")
 		}
 
 		//Whitelisting milestone
 		if blockHeaderVal1.Number.Uint64() == 15 {
 			blockHash := blockHeaderVal1.Hash()
-			nodes[0].Downloader().ChainValidator.ProcessMilestone(blockHeaderVal1.Number.Uint64(), blockHash)
+			nodes[0].Downloader().ChainValidator.ProcessMilestone(blockHeaderVal1.Number.Uint64(), blockHash, "")
 		}
 
 		//Whitelisting milestone
 		if blockHeaderVal1.Number.Uint64() == 23 {
 			blockHash := blockHeaderVal1.Hash()
-			nodes[0].Downloader().ChainValidator.ProcessMilestone(blockHeaderVal1.Number.Uint64(), blockHash)
+			nodes[0].Downloader().ChainValidator.ProcessMilestone(blockHeaderVal1.Number.Uint64(), blockHash, "")
 		}
 
 		if blockHeaderVal0.Number.Uint64() == 30 {
@@ -1295,7 +1295,7 @@ func TestRewinding(t *testing.T) {
 		//Processing the milestone
 		if blockHeaderVal0.Number.Uint64() == 7 {
 			blockHash := blockHeaderVal1.Hash()
-			nodes[0].Downloader().ChainValidator.ProcessMilestone(blockHeaderVal1.Number.Uint64(), blockHash)
+			nodes[0].Downloader().ChainValidator.ProcessMilestone(blockHeaderVal1.Number.Uint64(), blockHash, "")
 		}
 
 		//Verify the wrong hash to rewind back
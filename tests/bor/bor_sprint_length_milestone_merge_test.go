@@ -417,7 +417,7 @@ func SetupValidatorsAndTest2NodesSprintLengthMilestone(t *testing.T, tt map[stri
 
 		if math.Mod(float64(blockHeaderObserver.Number.Uint64()), float64(milestoneLength)) == 0 {
 			blockHash := blockHeaderObserver.Hash()
-			nodes[subscribedNodeIndex].Downloader().ChainValidator.ProcessMilestone(blockHeaderObserver.Number.Uint64(), blockHash)
+			nodes[subscribedNodeIndex].Downloader().ChainValidator.ProcessMilestone(blockHeaderObserver.Number.Uint64(), blockHash, "")
 		}
 
 		if blockHeaderObserver.Number.Uint64() == tt["startBlock"].(uint64)+tt["reorgLength"].(uint64) {
@@ -574,7 +574,7 @@ func SetupValidatorsAndTestSprintLengthMilestone(t *testing.T, tt map[string]uin
 				for _, nodeTemp := range nodes {
 					_, _, err := borVerifyTemP(nodeTemp, milestoneNum-milestoneLength+1, milestoneNum, milestoneHash.String())
 					if err == nil {
-						nodeTemp.Downloader().ChainValidator.ProcessMilestone(milestoneNum, milestoneHash)
+						nodeTemp.Downloader().ChainValidator.ProcessMilestone(milestoneNum, milestoneHash, "")
 					} else {
 						nodeTemp.Downloader().ChainValidator.ProcessFutureMilestone(milestoneNum, milestoneHash)
 					}
@@ -387,6 +387,8 @@ func TestInsertingSpanSizeBlocks(t *testing.T) {
 		EndBlock:   big.NewInt(int64(spanSize)),
 	}, nil).AnyTimes()
 
+	h.EXPECT().FetchCheckpointCount(gomock.Any()).Return(int64(0), nil).AnyTimes()
+
 	h.EXPECT().FetchMilestone(gomock.Any()).Return(&milestone.Milestone{
 		Proposer:   currentSpan.SelectedProducers[0].Address,
 		StartBlock: big.NewInt(0),
@@ -467,6 +469,7 @@ func TestFetchStateSyncEvents(t *testing.T) {
 	h.EXPECT().Span(gomock.Any(), uint64(1)).Return(&res.Result, nil).AnyTimes()
 
 	h.EXPECT().FetchCheckpoint(gomock.Any(), int64(-1)).Return(&checkpoint.Checkpoint{}, nil).AnyTimes()
+	h.EXPECT().FetchCheckpointCount(gomock.Any()).Return(int64(0), nil).AnyTimes()
 
 	h.EXPECT().FetchMilestone(gomock.Any()).Return(&milestone.Milestone{}, nil).AnyTimes()
 
@@ -526,6 +529,7 @@ func TestFetchStateSyncEvents_2(t *testing.T) {
 	h.EXPECT().Span(gomock.Any(), uint64(1)).Return(&res.Result, nil).AnyTimes()
 
 	h.EXPECT().FetchCheckpoint(gomock.Any(), int64(-1)).Return(&checkpoint.Checkpoint{}, nil).AnyTimes()
+	h.EXPECT().FetchCheckpointCount(gomock.Any()).Return(int64(0), nil).AnyTimes()
 
 	h.EXPECT().FetchMilestone(gomock.Any()).Return(&milestone.Milestone{}, nil).AnyTimes()
 
@@ -624,6 +628,7 @@ func TestOutOfTurnSigning(t *testing.T) {
 	h.EXPECT().Close().AnyTimes()
 
 	h.EXPECT().FetchCheckpoint(gomock.Any(), int64(-1)).Return(&checkpoint.Checkpoint{}, nil).AnyTimes()
+	h.EXPECT().FetchCheckpointCount(gomock.Any()).Return(int64(0), nil).AnyTimes()
 
 	h.EXPECT().FetchMilestone(gomock.Any()).Return(&milestone.Milestone{}, nil).AnyTimes()
 
@@ -714,6 +719,7 @@ func TestSignerNotFound(t *testing.T) {
 
 	h.EXPECT().Close().AnyTimes()
 	h.EXPECT().FetchCheckpoint(gomock.Any(), int64(-1)).Return(&checkpoint.Checkpoint{}, nil).AnyTimes()
+	h.EXPECT().FetchCheckpointCount(gomock.Any()).Return(int64(0), nil).AnyTimes()
 
 	h.EXPECT().FetchMilestone(gomock.Any()).Return(&milestone.Milestone{}, nil).AnyTimes()
 
@@ -0,0 +1,19 @@
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"testing"
+)
+
+func TestBor(t *testing.T) {
+	t.Parallel()
+
+	tm := new(testMatcher)
+	tm.walk(t, borTestDir, func(t *testing.T, name string, test *BorTest) {
+		if err := tm.checkFailure(t, test.Run()); err != nil {
+			t.Errorf("in 'bor_test.go', test '%s' failed with error: '%v'", name, err)
+		}
+	})
+}
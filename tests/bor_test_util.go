@@ -0,0 +1,316 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/contract"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// A BorTest checks the handling of a chain of bor blocks against the bor
+// consensus rules, with the heimdall responses mocked by the fixture.
+type BorTest struct {
+	json borJSON
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (t *BorTest) UnmarshalJSON(in []byte) error {
+	return json.Unmarshal(in, &t.json)
+}
+
+type borJSON struct {
+	Genesis   *core.Genesis     `json:"genesis"`
+	Heimdall  borHeimdallJSON   `json:"heimdall"`
+	Blocks    []borBlock        `json:"blocks"`
+	BestBlock common.Hash       `json:"lastblockhash"`
+	Post      core.GenesisAlloc `json:"postState"`
+}
+
+// borHeimdallJSON holds the responses of the mocked heimdall.
+type borHeimdallJSON struct {
+	Spans      []*span.HeimdallSpan         `json:"spans"`
+	StateSyncs []*clerk.EventRecordWithTime `json:"stateSyncs"`
+}
+
+// borBlock is a block to import. Blocks with an expected exception must be
+// rejected, either by VerifyHeader or while being processed and finalized,
+// with an error containing the exception.
+type borBlock struct {
+	Rlp             hexutil.Bytes `json:"rlp"`
+	ExpectException string        `json:"expectException,omitempty"`
+	Comment         string        `json:"comment,omitempty"`
+}
+
+// Run imports the blocks of the test one by one and checks the outcome of
+// each of them, then the resulting head and state.
+func (t *BorTest) Run() error {
+	if t.json.Genesis == nil || t.json.Genesis.Config == nil || t.json.Genesis.Config.Bor == nil {
+		return errors.New("bor test without bor genesis")
+	}
+
+	db := rawdb.NewMemoryDatabase()
+
+	engine, caller := newBorTestEngine(t.json.Genesis, db, newBorTestHeimdall(t.json.Heimdall))
+	defer engine.Close()
+
+	chain, err := core.NewBlockChain(db, nil, t.json.Genesis, nil, engine, vm.Config{}, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer chain.Stop()
+
+	caller.chain = chain
+
+	for i, b := range t.json.Blocks {
+		err := t.insertBlock(chain, engine, b)
+
+		switch {
+		case b.ExpectException == "" && err != nil:
+			return fmt.Errorf("block (index %d) rejected: %v", i, err)
+		case b.ExpectException != "" && err == nil:
+			return fmt.Errorf("block (index %d) accepted, expected to fail due to: %v", i, b.ExpectException)
+		case b.ExpectException != "" && !strings.Contains(err.Error(), b.ExpectException):
+			return fmt.Errorf("block (index %d) rejected due to: %v, expected: %v", i, err, b.ExpectException)
+		}
+	}
+
+	if t.json.BestBlock != (common.Hash{}) && chain.CurrentBlock().Hash() != t.json.BestBlock {
+		return fmt.Errorf("last block hash validation mismatch: want: %x, have: %x", t.json.BestBlock, chain.CurrentBlock().Hash())
+	}
+
+	statedb, err := chain.State()
+	if err != nil {
+		return err
+	}
+
+	return validateBorPostState(t.json.Post, statedb)
+}
+
+// insertBlock verifies the header of the block, then imports it.
+func (t *BorTest) insertBlock(chain *core.BlockChain, engine *bor.Bor, b borBlock) error {
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(b.Rlp, block); err != nil {
+		return fmt.Errorf("rlp: %w", err)
+	}
+
+	if err := engine.VerifyHeader(chain, block.Header(), true); err != nil {
+		return err
+	}
+
+	_, err := chain.InsertChain(types.Blocks{block})
+
+	return err
+}
+
+func validateBorPostState(post core.GenesisAlloc, statedb *state.StateDB) error {
+	for addr, acct := range post {
+		if code := statedb.GetCode(addr); !bytes.Equal(code, acct.Code) {
+			return fmt.Errorf("account code mismatch for addr: %s want: %x have: %x", addr, acct.Code, code)
+		}
+
+		if acct.Balance != nil && statedb.GetBalance(addr).Cmp(acct.Balance) != 0 {
+			return fmt.Errorf("account balance mismatch for addr: %s, want: %d, have: %d", addr, acct.Balance, statedb.GetBalance(addr))
+		}
+
+		if nonce := statedb.GetNonce(addr); nonce != acct.Nonce {
+			return fmt.Errorf("account nonce mismatch for addr: %s want: %d have: %d", addr, acct.Nonce, nonce)
+		}
+
+		for key, value := range acct.Storage {
+			if have := statedb.GetState(addr, key); have != value {
+				return fmt.Errorf("account storage mismatch for addr: %s, slot: %x, want: %x, have: %x", addr, key, value, have)
+			}
+		}
+	}
+
+	return nil
+}
+
+// newBorTestEngine creates the bor engine of a test, calling the genesis
+// contracts through the returned caller once it's attached to the chain.
+func newBorTestEngine(genesis *core.Genesis, db ethdb.Database, heimdall bor.IHeimdallClient) (*bor.Bor, *borTestCaller) {
+	var (
+		config  = genesis.Config
+		caller  = &borTestCaller{}
+		spanner = span.NewChainSpanner(caller, contract.ValidatorSet(), config, common.HexToAddress(config.Bor.ValidatorContract))
+		client  = contract.NewGenesisContractsClient(config, config.Bor.ValidatorContract, config.Bor.StateReceiverContract, caller)
+	)
+
+	return bor.New(config, db, caller, spanner, heimdall, client, false), caller
+}
+
+// borTestCaller executes the calls of the bor engine to the genesis contracts
+// against the state of the test chain.
+type borTestCaller struct {
+	chain *core.BlockChain
+}
+
+func (c *borTestCaller) header(blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
+	var header *types.Header
+
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		header = c.chain.GetHeaderByHash(hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		if number < 0 {
+			header = c.chain.CurrentHeader()
+		} else {
+			header = c.chain.GetHeaderByNumber(uint64(number))
+		}
+	}
+
+	if header == nil {
+		return nil, errors.New("header not found")
+	}
+
+	return header, nil
+}
+
+// Call implements api.Caller, executing the call on the state of the block.
+func (c *borTestCaller) Call(_ context.Context, args ethapi.TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *ethapi.StateOverride) (hexutil.Bytes, error) {
+	header, err := c.header(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	statedb, err := c.chain.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.call(args, header, statedb, overrides)
+}
+
+// CallWithState implements api.Caller, executing the call on the given state,
+// or on the state of the block if none is given.
+func (c *borTestCaller) CallWithState(ctx context.Context, args ethapi.TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, statedb *state.StateDB, overrides *ethapi.StateOverride) (hexutil.Bytes, error) {
+	if statedb == nil {
+		return c.Call(ctx, args, blockNrOrHash, overrides)
+	}
+
+	header, err := c.header(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.call(args, header, statedb, overrides)
+}
+
+func (c *borTestCaller) call(args ethapi.TransactionArgs, header *types.Header, statedb *state.StateDB, overrides *ethapi.StateOverride) (hexutil.Bytes, error) {
+	if err := overrides.Apply(statedb); err != nil {
+		return nil, err
+	}
+
+	msg, err := args.ToMessage(0, header.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+
+	evm := vm.NewEVM(core.NewEVMBlockContext(header, c.chain, nil), core.NewEVMTxContext(msg), statedb, c.chain.Config(), vm.Config{NoBaseFee: true})
+
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64), context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Return(), result.Err
+}
+
+var errBorTestNotMocked = errors.New("not mocked in bor tests")
+
+// borTestHeimdall serves the spans and state-sync events of a test.
+type borTestHeimdall struct {
+	spans      map[uint64]*span.HeimdallSpan
+	stateSyncs []*clerk.EventRecordWithTime
+}
+
+func newBorTestHeimdall(responses borHeimdallJSON) *borTestHeimdall {
+	h := &borTestHeimdall{
+		spans:      make(map[uint64]*span.HeimdallSpan, len(responses.Spans)),
+		stateSyncs: append([]*clerk.EventRecordWithTime{}, responses.StateSyncs...),
+	}
+
+	for _, s := range responses.Spans {
+		h.spans[s.ID] = s
+	}
+
+	sort.Slice(h.stateSyncs, func(i, j int) bool {
+		return h.stateSyncs[i].ID < h.stateSyncs[j].ID
+	})
+
+	return h
+}
+
+// StateSyncEvents returns the events from fromID on, recorded before to, as
+// heimdall does.
+func (h *borTestHeimdall) StateSyncEvents(_ context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	events := make([]*clerk.EventRecordWithTime, 0)
+
+	for _, event := range h.stateSyncs {
+		if event.ID >= fromID && event.Time.Unix() < to {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (h *borTestHeimdall) Span(_ context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	s, ok := h.spans[spanID]
+	if !ok {
+		return nil, fmt.Errorf("span %d %w", spanID, errBorTestNotMocked)
+	}
+
+	return s, nil
+}
+
+func (h *borTestHeimdall) FetchCheckpoint(context.Context, int64) (*checkpoint.Checkpoint, error) {
+	return nil, errBorTestNotMocked
+}
+
+func (h *borTestHeimdall) FetchCheckpointCount(context.Context) (int64, error) {
+	return 0, errBorTestNotMocked
+}
+
+func (h *borTestHeimdall) FetchMilestone(context.Context) (*milestone.Milestone, error) {
+	return nil, errBorTestNotMocked
+}
+
+func (h *borTestHeimdall) FetchMilestoneCount(context.Context) (int64, error) {
+	return 0, errBorTestNotMocked
+}
+
+func (h *borTestHeimdall) FetchNoAckMilestone(context.Context, string) error {
+	return errBorTestNotMocked
+}
+
+func (h *borTestHeimdall) FetchLastNoAckMilestone(context.Context) (string, error) {
+	return "", errBorTestNotMocked
+}
+
+func (h *borTestHeimdall) FetchMilestoneID(context.Context, string) error {
+	return errBorTestNotMocked
+}
+
+func (h *borTestHeimdall) Close() {}
@@ -44,6 +44,7 @@ var (
 	rlpTestDir         = filepath.Join(baseDir, "RLPTests")
 	difficultyTestDir  = filepath.Join(baseDir, "BasicTests")
 	benchmarksDir      = filepath.Join(".", "evm-benchmarks", "benchmarks")
+	borTestDir         = filepath.Join(".", "bor", "testdata", "conformance")
 )
 
 func readJSON(reader io.Reader, value interface{}) error {